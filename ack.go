@@ -0,0 +1,53 @@
+package slacker
+
+import (
+	"sync"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+const (
+	// ackTimeout leaves some room within the 3 seconds Slack waits for acknowledgements
+	ackTimeout = 2500 * time.Millisecond
+)
+
+// newAcknowledger creates a new acknowledger structure, sending an empty
+// acknowledgement once the timeout elapses if none was sent yet
+func newAcknowledger(timeout time.Duration, send func(payload any)) *acknowledger {
+	acknowledger := &acknowledger{send: send}
+	acknowledger.timer = time.AfterFunc(timeout, func() {
+		acknowledger.ack(nil)
+	})
+	return acknowledger
+}
+
+// acknowledger sends the acknowledgement of a request, at most once. Requests
+// acknowledged with a payload wait for their handler to acknowledge them.
+type acknowledger struct {
+	once  sync.Once
+	timer *time.Timer
+	send  func(payload any)
+}
+
+// ack sends the acknowledgement with the payload, unless already sent. A nil
+// acknowledger ignores the call.
+func (a *acknowledger) ack(payload any) {
+	if a == nil {
+		return
+	}
+
+	a.once.Do(func() {
+		if a.timer != nil {
+			a.timer.Stop()
+		}
+		a.send(payload)
+	})
+}
+
+// requiresAckPayload determines whether the interaction can be acknowledged
+// with a payload, so that acknowledging it waits for its handler
+func requiresAckPayload(callback *slack.InteractionCallback) bool {
+	return callback.Type == slack.InteractionTypeViewSubmission ||
+		callback.Type == slack.InteractionTypeBlockSuggestion
+}
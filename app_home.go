@@ -0,0 +1,99 @@
+package slacker
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/slack-go/slack"
+	"github.com/slack-go/slack/slackevents"
+)
+
+const (
+	appHomeTab           = "home"
+	spanPublishView      = "slack.views.publish"
+	appHomeNotDefined    = "app home is not defined"
+	appHomeTriggerOpened = "app_home_opened"
+	appHomeTriggerAction = "block_actions"
+	appHomeTriggerManual = "publish"
+)
+
+// AppHomeDefinition structure contains definition of the bot's App Home tab
+type AppHomeDefinition struct {
+	Middlewares []AppHomeMiddlewareHandler
+
+	// Handler renders the home view of a user by calling AppHomeContext.Render.
+	// The view is published once the handler returns.
+	Handler AppHomeHandler
+
+	// RefreshOnAction republishes the home view of the user once an interaction
+	// handled a block action from the home tab
+	RefreshOnAction bool
+}
+
+// isAppHomeAction determines if the callback is a block action from the App Home tab
+func isAppHomeAction(callback *slack.InteractionCallback) bool {
+	return callback.Type == slack.InteractionTypeBlockActions && callback.View.Type == slack.VTHomeTab
+}
+
+// interactionChannelID returns the channel to reply to an interaction in. Block
+// actions from the App Home tab have no channel, so replies go to the user's
+// messages tab instead.
+func interactionChannelID(callback *slack.InteractionCallback) string {
+	if len(callback.Channel.ID) == 0 && callback.View.Type == slack.VTHomeTab {
+		return callback.User.ID
+	}
+	return callback.Channel.ID
+}
+
+// PublishAppHome renders and publishes the App Home tab of the user. Use it to
+// refresh the home view from commands, jobs or interactions.
+func (s *Slacker) PublishAppHome(ctx context.Context, userID string) error {
+	return s.publishAppHome(ctx, userID, nil, appHomeTriggerManual)
+}
+
+// handleAppHomeEvent publishes the home view of the user opening the home tab,
+// returning false if there is no App Home to publish
+func (s *Slacker) handleAppHomeEvent(ctx context.Context, event *slackevents.AppHomeOpenedEvent) bool {
+	if s.appHome == nil || event.Tab != appHomeTab {
+		return false
+	}
+
+	s.publishAppHome(ctx, event.User, event, appHomeTriggerOpened)
+	return true
+}
+
+// publishAppHome runs the App Home handler for the user and publishes the rendered view.
+// Failures are reported to the OnError hook, and returned.
+func (s *Slacker) publishAppHome(ctx context.Context, userID string, event *slackevents.AppHomeOpenedEvent, trigger string) error {
+	if s.appHome == nil {
+		return errors.New(appHomeNotDefined)
+	}
+
+	s.metrics.appHomeRendered(trigger)
+	defer s.metrics.handlerDone(ContextTypeAppHome, trigger, time.Now())
+
+	handlerCtx, handlerSpan := StartSpan(ctx, spanHandler)
+	homeCtx := newAppHomeContext(handlerCtx, s.logger, s.metrics, s.slackClient, userID, event, s.appHome)
+	executeAppHome(homeCtx, s.errorReporter, s.appHome.Handler, s.appHome.Middlewares...)
+	endSpan(handlerSpan, homeCtx.err)
+	if homeCtx.err != nil {
+		return homeCtx.err
+	}
+
+	if homeCtx.view == nil {
+		homeCtx.logger.Debug("app home handler rendered no view")
+		return nil
+	}
+
+	spanCtx, span := StartSpan(ctx, spanPublishView)
+	defer span.End()
+
+	_, err := s.slackClient.PublishViewContext(spanCtx, userID, *homeCtx.view, "")
+	if err != nil {
+		span.RecordError(err)
+		s.errorReporter.report(newAppHomeError(homeCtx, err))
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package slacker_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/shomali11/slacker/v2"
+	"github.com/shomali11/slacker/v2/slackertest"
+	"github.com/slack-go/slack"
+)
+
+// newCounterAppHome creates an App Home showing how many times the user
+// clicked its button, incremented by an interaction
+func newCounterAppHome(harness *slackertest.Harness, refreshOnAction bool) {
+	clicks := 0
+	harness.Bot().AppHome(&slacker.AppHomeDefinition{
+		RefreshOnAction: refreshOnAction,
+		Handler: func(ctx *slacker.AppHomeContext) {
+			text := slack.NewTextBlockObject(slack.MarkdownType, fmt.Sprintf("<@%s> clicked %d times", ctx.UserID(), clicks), false, false)
+			ctx.Render(slack.HomeTabViewRequest{
+				Type:   slack.VTHomeTab,
+				Blocks: slack.Blocks{BlockSet: []slack.Block{slack.NewSectionBlock(text, nil, nil)}},
+			})
+		},
+	})
+	harness.Bot().AddInteraction(&slacker.InteractionDefinition{
+		Type:     slack.InteractionTypeBlockActions,
+		ActionID: "click",
+		Handler: func(*slacker.InteractionContext) {
+			clicks++
+		},
+	})
+}
+
+// homeText returns the text of the home view published for the user
+func homeText(t *testing.T, harness *slackertest.Harness, userID string) string {
+	t.Helper()
+
+	view, ok := harness.Server().HomeView(userID)
+	if !ok || len(view.Blocks.BlockSet) != 1 {
+		t.Fatalf("expected a home view to be published for %s, got %+v", userID, view)
+	}
+	return view.Blocks.BlockSet[0].(*slack.SectionBlock).Text.Text
+}
+
+// publishCount returns how many views were published
+func publishCount(harness *slackertest.Harness) int {
+	count := 0
+	for _, call := range harness.Server().Calls() {
+		if call.Method == "views.publish" {
+			count++
+		}
+	}
+	return count
+}
+
+// newHomeTabAction creates a block action from the home tab of the user
+func newHomeTabAction(userID string, actionID string) *slack.InteractionCallback {
+	callback := &slack.InteractionCallback{Type: slack.InteractionTypeBlockActions}
+	callback.User.ID = userID
+	callback.View.Type = slack.VTHomeTab
+	callback.ActionCallback.BlockActions = []*slack.BlockAction{{ActionID: actionID}}
+	return callback
+}
+
+func TestAppHomePublishedOnOpen(t *testing.T) {
+	harness := slackertest.NewHarness()
+	defer harness.Close()
+
+	newCounterAppHome(harness, false)
+	harness.OpenAppHome("U123")
+
+	if count := publishCount(harness); count != 1 {
+		t.Fatalf("expected views.publish to be called once, got %d", count)
+	}
+	if text := homeText(t, harness, "U123"); text != "<@U123> clicked 0 times" {
+		t.Errorf("expected the rendered view to be published, got %q", text)
+	}
+}
+
+func TestAppHomeRefreshOnAction(t *testing.T) {
+	harness := slackertest.NewHarness()
+	defer harness.Close()
+
+	newCounterAppHome(harness, true)
+	harness.OpenAppHome("U123")
+	harness.SendInteraction(newHomeTabAction("U123", "click"))
+
+	if count := publishCount(harness); count != 2 {
+		t.Fatalf("expected the home view to be published again, got %d publishes", count)
+	}
+	if text := homeText(t, harness, "U123"); text != "<@U123> clicked 1 times" {
+		t.Errorf("expected the republished view to reflect the action, got %q", text)
+	}
+}
+
+func TestAppHomeNotRefreshedWithoutRefreshOnAction(t *testing.T) {
+	harness := slackertest.NewHarness()
+	defer harness.Close()
+
+	newCounterAppHome(harness, false)
+	harness.OpenAppHome("U123")
+	harness.SendInteraction(newHomeTabAction("U123", "click"))
+
+	if count := publishCount(harness); count != 1 {
+		t.Errorf("expected the home view to be published only when opened, got %d publishes", count)
+	}
+}
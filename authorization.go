@@ -0,0 +1,205 @@
+package slacker
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/slack-go/slack"
+)
+
+const (
+	// RoleAdmin is granted by NewAdminRoleProvider to workspace admins
+	RoleAdmin = "admin"
+
+	// RoleOwner is granted by NewAdminRoleProvider to workspace owners
+	RoleOwner = "owner"
+
+	// RolePrimaryOwner is granted by NewAdminRoleProvider to the workspace's primary owner
+	RolePrimaryOwner = "primary_owner"
+
+	unauthorizedCommandMessage     = "You are not authorized to run this command."
+	unauthorizedInteractionMessage = "You are not authorized to perform this action."
+	userGroupCacheKey              = "usergroup:"
+)
+
+// RoleProvider resolves the roles granted to a user
+type RoleProvider interface {
+	Roles(ctx context.Context, userID string) ([]string, error)
+}
+
+// RoleProviderFunc adapts a function into a RoleProvider
+type RoleProviderFunc func(ctx context.Context, userID string) ([]string, error)
+
+// Roles resolves the roles granted to a user
+func (f RoleProviderFunc) Roles(ctx context.Context, userID string) ([]string, error) {
+	return f(ctx, userID)
+}
+
+// NewStaticRoleProvider grants roles to fixed lists of user IDs, keyed by role
+func NewStaticRoleProvider(members map[string][]string) RoleProvider {
+	return RoleProviderFunc(func(_ context.Context, userID string) ([]string, error) {
+		roles := make([]string, 0)
+		for role, userIDs := range members {
+			for _, memberID := range userIDs {
+				if memberID == userID {
+					roles = append(roles, role)
+					break
+				}
+			}
+		}
+		return roles, nil
+	})
+}
+
+// NewUserGroupRoleProvider grants roles to the members of Slack user groups.
+// The map is keyed by role and its values are user group IDs. Memberships are
+// cached, for 5 minutes by default, see WithUserGroupCache.
+// OAuth scope `usergroups:read` is required for this provider.
+func NewUserGroupRoleProvider(slackClient *slack.Client, userGroups map[string]string, options ...UserGroupRoleProviderOption) RoleProvider {
+	providerOptions := newUserGroupRoleProviderOptions(options...)
+	return RoleProviderFunc(func(ctx context.Context, userID string) ([]string, error) {
+		roles := make([]string, 0)
+		for role, userGroupID := range userGroups {
+			memberIDs, err := getUserGroupMembers(ctx, slackClient, providerOptions.Cache, userGroupID)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, memberID := range memberIDs {
+				if memberID == userID {
+					roles = append(roles, role)
+					break
+				}
+			}
+		}
+		return roles, nil
+	})
+}
+
+// getUserGroupMembers returns the IDs of the members of the user group, cached if possible
+func getUserGroupMembers(ctx context.Context, slackClient *slack.Client, cache Cache, userGroupID string) ([]string, error) {
+	memberIDs := make([]string, 0)
+	if getCached(cache, userGroupCacheKey+userGroupID, &memberIDs) {
+		return memberIDs, nil
+	}
+
+	memberIDs, err := slackClient.GetUserGroupMembersContext(ctx, userGroupID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get members of user group %s: %w", userGroupID, err)
+	}
+
+	setCached(cache, userGroupCacheKey+userGroupID, memberIDs)
+	return memberIDs, nil
+}
+
+// NewAdminRoleProvider grants RoleAdmin, RoleOwner and RolePrimaryOwner based on
+// the user's workspace flags. OAuth scope `users:read` is required for this provider.
+func NewAdminRoleProvider(slackClient *slack.Client) RoleProvider {
+	return RoleProviderFunc(func(ctx context.Context, userID string) ([]string, error) {
+		user, err := slackClient.GetUserInfoContext(ctx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get user info for %s: %w", userID, err)
+		}
+
+		roles := make([]string, 0)
+		if user.IsAdmin {
+			roles = append(roles, RoleAdmin)
+		}
+
+		if user.IsOwner {
+			roles = append(roles, RoleOwner)
+		}
+
+		if user.IsPrimaryOwner {
+			roles = append(roles, RolePrimaryOwner)
+		}
+		return roles, nil
+	})
+}
+
+// NewCompositeRoleProvider grants the roles of all the given providers
+func NewCompositeRoleProvider(providers ...RoleProvider) RoleProvider {
+	return RoleProviderFunc(func(ctx context.Context, userID string) ([]string, error) {
+		roles := make([]string, 0)
+		for _, provider := range providers {
+			providerRoles, err := provider.Roles(ctx, userID)
+			if err != nil {
+				return nil, err
+			}
+			roles = append(roles, providerRoles...)
+		}
+		return roles, nil
+	})
+}
+
+// newAuthorizer creates a new authorizer for a user
+func newAuthorizer(ctx context.Context, logger StructuredLogger, provider RoleProvider, userID string) *authorizer {
+	return &authorizer{ctx: ctx, logger: logger, provider: provider, userID: userID}
+}
+
+// authorizer checks a user's roles, resolving them at most once
+type authorizer struct {
+	ctx      context.Context
+	logger   StructuredLogger
+	provider RoleProvider
+	userID   string
+	roles    map[string]bool
+}
+
+// isAuthorized determines whether the user holds at least one role of every non empty set
+func (a *authorizer) isAuthorized(roleSets ...[]string) bool {
+	for _, roles := range roleSets {
+		if len(roles) == 0 {
+			continue
+		}
+
+		if !a.hasAnyRole(roles) {
+			return false
+		}
+	}
+	return true
+}
+
+func (a *authorizer) hasAnyRole(roles []string) bool {
+	if a.roles == nil {
+		a.roles = a.resolveRoles()
+	}
+
+	for _, role := range roles {
+		if a.roles[role] {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *authorizer) resolveRoles() map[string]bool {
+	userRoles := make(map[string]bool)
+	if a.provider == nil {
+		a.logger.Error("roles are required but no role provider was configured")
+		return userRoles
+	}
+
+	roles, err := a.provider.Roles(a.ctx, a.userID)
+	if err != nil {
+		a.logger.Error("unable to resolve roles", logKeyUserID, a.userID, logKeyError, err)
+		return userRoles
+	}
+
+	for _, role := range roles {
+		userRoles[role] = true
+	}
+	return userRoles
+}
+
+func defaultUnauthorizedCommandHandler(ctx *CommandContext) {
+	ctx.Response().Reply(unauthorizedCommandMessage, WithEphemeral())
+}
+
+func defaultUnauthorizedInteractionHandler(ctx *InteractionContext) {
+	// Interactions such as shortcuts and view submissions do not have a channel to reply to
+	if len(ctx.Callback().Channel.ID) == 0 {
+		return
+	}
+	ctx.Response().Reply(unauthorizedInteractionMessage, WithEphemeral())
+}
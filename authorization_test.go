@@ -0,0 +1,219 @@
+package slacker_test
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/shomali11/slacker/v2"
+	"github.com/shomali11/slacker/v2/slackertest"
+	"github.com/slack-go/slack"
+)
+
+func TestUserGroupRoleProviderCachesMemberships(t *testing.T) {
+	server := slackertest.NewServer()
+	defer server.Close()
+
+	provider := slacker.NewUserGroupRoleProvider(
+		slack.New("xoxb-test", slack.OptionAPIURL(server.URL())),
+		map[string]string{"deployer": "S123"},
+	)
+
+	harness := slackertest.NewHarness(slacker.WithRoleProvider(provider))
+	defer harness.Close()
+
+	harness.Bot().AddCommand(&slacker.CommandDefinition{
+		Command: "deploy",
+		Roles:   []string{"deployer"},
+		Handler: func(ctx *slacker.CommandContext) {},
+	})
+
+	harness.SendMessage("C123", "U123", "deploy")
+	harness.SendMessage("C123", "U123", "deploy")
+
+	lookups := 0
+	for _, call := range server.Calls() {
+		if call.Method == "usergroups.users.list" {
+			lookups++
+		}
+	}
+	if lookups != 1 {
+		t.Errorf("expected the memberships to be looked up once, got %d lookups", lookups)
+	}
+
+	messages := harness.Messages()
+	if len(messages) != 2 || !messages[0].IsEphemeral() {
+		t.Errorf("expected 2 unauthorized replies, got %+v", messages)
+	}
+}
+
+func TestStaticRoleProvider(t *testing.T) {
+	provider := slacker.NewStaticRoleProvider(map[string][]string{
+		"deployer": {"U1", "U2"},
+		"auditor":  {"U2"},
+	})
+
+	tests := []struct {
+		userID string
+		roles  []string
+	}{
+		{userID: "U1", roles: []string{"deployer"}},
+		{userID: "U2", roles: []string{"auditor", "deployer"}},
+		{userID: "U3", roles: []string{}},
+	}
+
+	for _, test := range tests {
+		roles, err := provider.Roles(context.Background(), test.userID)
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+
+		sort.Strings(roles)
+		if len(roles) != len(test.roles) || (len(roles) > 0 && !reflect.DeepEqual(roles, test.roles)) {
+			t.Errorf("expected %s to hold %v, got %v", test.userID, test.roles, roles)
+		}
+	}
+}
+
+func TestAdminRoleProvider(t *testing.T) {
+	server := slackertest.NewServer()
+	defer server.Close()
+
+	server.AddUser(slack.User{ID: "U1", IsAdmin: true, IsOwner: true, IsPrimaryOwner: true})
+	server.AddUser(slack.User{ID: "U2"})
+
+	provider := slacker.NewAdminRoleProvider(slack.New("xoxb-test", slack.OptionAPIURL(server.URL())))
+
+	roles, err := provider.Roles(context.Background(), "U1")
+	if err != nil || !reflect.DeepEqual(roles, []string{slacker.RoleAdmin, slacker.RoleOwner, slacker.RolePrimaryOwner}) {
+		t.Errorf("expected the admin roles, got %v %v", roles, err)
+	}
+
+	roles, err = provider.Roles(context.Background(), "U2")
+	if err != nil || len(roles) != 0 {
+		t.Errorf("expected no roles, got %v %v", roles, err)
+	}
+}
+
+func TestCommandRoles(t *testing.T) {
+	provider := slacker.NewStaticRoleProvider(map[string][]string{
+		"ops":      {"U1", "U2"},
+		"deployer": {"U1"},
+	})
+
+	harness := slackertest.NewHarness(slacker.WithRoleProvider(provider))
+	defer harness.Close()
+
+	var handled []string
+	group := harness.Bot().AddCommandGroup("ops")
+	group.AddRoles("ops")
+	group.AddCommand(&slacker.CommandDefinition{
+		Command: "status",
+		Handler: func(ctx *slacker.CommandContext) {
+			handled = append(handled, "status "+ctx.Event().UserID)
+		},
+	})
+	group.AddCommand(&slacker.CommandDefinition{
+		Command: "deploy",
+		Roles:   []string{"deployer"},
+		Handler: func(ctx *slacker.CommandContext) {
+			handled = append(handled, "deploy "+ctx.Event().UserID)
+		},
+	})
+
+	tests := []struct {
+		userID     string
+		text       string
+		authorized bool
+	}{
+		{userID: "U1", text: "ops status", authorized: true},
+		{userID: "U2", text: "ops status", authorized: true},
+		{userID: "U3", text: "ops status", authorized: false},
+		{userID: "U1", text: "ops deploy", authorized: true},
+		{userID: "U2", text: "ops deploy", authorized: false},
+	}
+
+	for _, test := range tests {
+		harness.Reset()
+		handled = nil
+
+		harness.SendMessage("C123", test.userID, test.text)
+
+		if authorized := len(handled) == 1; authorized != test.authorized {
+			t.Errorf("expected %s running %q authorized %t, got %t", test.userID, test.text, test.authorized, authorized)
+		}
+
+		messages := harness.Messages()
+		if !test.authorized && (len(messages) != 1 || !messages[0].IsEphemeral() || messages[0].EphemeralUserID != test.userID) {
+			t.Errorf("expected an ephemeral unauthorized reply to %s, got %+v", test.userID, messages)
+		}
+	}
+}
+
+func TestInteractionRoles(t *testing.T) {
+	provider := slacker.NewStaticRoleProvider(map[string][]string{"approver": {"U1"}})
+
+	harness := slackertest.NewHarness(slacker.WithRoleProvider(provider))
+	defer harness.Close()
+
+	var approvedBy []string
+	harness.Bot().AddInteraction(&slacker.InteractionDefinition{
+		Type:     slack.InteractionTypeBlockActions,
+		ActionID: "approve",
+		Roles:    []string{"approver"},
+		Handler: func(ctx *slacker.InteractionContext) {
+			approvedBy = append(approvedBy, ctx.Callback().User.ID)
+		},
+	})
+
+	for _, userID := range []string{"U1", "U2"} {
+		callback := &slack.InteractionCallback{Type: slack.InteractionTypeBlockActions}
+		callback.Channel.ID = "C123"
+		callback.User.ID = userID
+		callback.ActionCallback.BlockActions = []*slack.BlockAction{{ActionID: "approve"}}
+		harness.SendInteraction(callback)
+	}
+
+	if !reflect.DeepEqual(approvedBy, []string{"U1"}) {
+		t.Errorf("expected only U1 to approve, got %v", approvedBy)
+	}
+
+	messages := harness.Messages()
+	if len(messages) != 1 || !messages[0].IsEphemeral() || messages[0].EphemeralUserID != "U2" {
+		t.Errorf("expected an ephemeral unauthorized reply to U2, got %+v", messages)
+	}
+}
+
+func TestHelpHidesUnauthorizedCommands(t *testing.T) {
+	provider := slacker.NewStaticRoleProvider(map[string][]string{"admin": {"U1"}})
+
+	harness := slackertest.NewHarness(slacker.WithRoleProvider(provider))
+	defer harness.Close()
+
+	harness.Bot().AddCommand(&slacker.CommandDefinition{Command: "ping", Handler: func(*slacker.CommandContext) {}})
+	harness.Bot().AddCommand(&slacker.CommandDefinition{Command: "shutdown", Roles: []string{"admin"}, Handler: func(*slacker.CommandContext) {}})
+
+	help := func(userID string) string {
+		harness.Reset()
+		harness.SendMessage("C123", userID, "help")
+
+		text := ""
+		for _, message := range harness.Messages() {
+			for _, block := range message.Blocks {
+				if section, ok := block.(*slack.SectionBlock); ok && section.Text != nil {
+					text += section.Text.Text + "\n"
+				}
+			}
+		}
+		return text
+	}
+
+	if text := help("U1"); !strings.Contains(text, "ping") || !strings.Contains(text, "shutdown") {
+		t.Errorf("expected the admin to see every command, got %q", text)
+	}
+	if text := help("U2"); !strings.Contains(text, "ping") || strings.Contains(text, "shutdown") {
+		t.Errorf("expected the restricted command to be hidden, got %q", text)
+	}
+}
@@ -0,0 +1,162 @@
+package slacker
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/shomali11/proper"
+)
+
+const (
+	bindTag     = "slacker"
+	bindSkipTag = "-"
+)
+
+var (
+	durationType = reflect.TypeOf(time.Duration(0))
+	timeType     = reflect.TypeOf(time.Time{})
+
+	errInvalidBindTarget = errors.New("bind target must be a pointer to a struct")
+)
+
+// TypedCommandHandler adapts a handler receiving the command's parameters bound
+// to a struct into a CommandHandler. See Request.Bind for the binding rules.
+// Input that cannot be bound is rejected with an ephemeral error reply.
+func TypedCommandHandler[T any](handler func(*CommandContext, *T)) CommandHandler {
+	return func(ctx *CommandContext) {
+		var args T
+		if err := ctx.Request().Bind(&args); err != nil {
+			ctx.Response().ReplyError(err, WithEphemeral())
+			return
+		}
+		handler(ctx, &args)
+	}
+}
+
+// bindProperties sets the fields of a struct tagged with `slacker:"name"` from the matched parameters
+func bindProperties(properties *proper.Properties, definitions []*ParameterDefinition, out any) error {
+	target := reflect.ValueOf(out)
+	if target.Kind() != reflect.Pointer || target.IsNil() || target.Elem().Kind() != reflect.Struct {
+		return errInvalidBindTarget
+	}
+
+	if properties == nil {
+		return nil
+	}
+
+	parameters := make(map[string]*ParameterDefinition)
+	for _, definition := range definitions {
+		parameters[definition.Name] = definition
+	}
+
+	target = target.Elem()
+	for i := 0; i < target.NumField(); i++ {
+		field := target.Type().Field(i)
+		name := field.Tag.Get(bindTag)
+		if len(name) == 0 || name == bindSkipTag || !field.IsExported() {
+			continue
+		}
+
+		value := properties.StringParam(name, empty)
+		if len(value) == 0 {
+			continue
+		}
+
+		if err := bindField(target.Field(i), value, parameters[name]); err != nil {
+			return fmt.Errorf("`%s` is invalid: %v", name, err)
+		}
+	}
+	return nil
+}
+
+func bindField(field reflect.Value, value string, definition *ParameterDefinition) error {
+	if field.Kind() == reflect.Pointer {
+		element := reflect.New(field.Type().Elem())
+		if err := bindField(element.Elem(), value, definition); err != nil {
+			return err
+		}
+		field.Set(element)
+		return nil
+	}
+
+	// Prefer the declared parameter type when its converted value fits the field
+	if definition != nil {
+		parsed, err := definition.Parse(value)
+		if err != nil {
+			return err
+		}
+
+		parsedValue := reflect.ValueOf(parsed)
+		if parsedValue.Type().AssignableTo(field.Type()) {
+			field.Set(parsedValue)
+			return nil
+		}
+	}
+
+	switch {
+	case field.Type() == durationType:
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(duration))
+	case field.Type() == timeType:
+		date, err := time.Parse(defaultDateLayout, value)
+		if err != nil {
+			return err
+		}
+		field.Set(reflect.ValueOf(date))
+	default:
+		return bindKind(field, value)
+	}
+	return nil
+}
+
+func bindKind(field reflect.Value, value string) error {
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(unwrapMention(value))
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(parsed)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		parsed, err := strconv.ParseInt(value, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(parsed)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		parsed, err := strconv.ParseUint(value, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(parsed)
+	case reflect.Float32, reflect.Float64:
+		parsed, err := strconv.ParseFloat(value, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetFloat(parsed)
+	default:
+		return fmt.Errorf("unsupported field type %s", field.Type())
+	}
+	return nil
+}
+
+// unwrapMention converts user and channel mentions into their IDs and leaves any other text untouched
+func unwrapMention(value string) string {
+	if userID, err := parseUserMention(value); err == nil {
+		return userID
+	}
+
+	if channelID, err := parseChannelMention(value); err == nil {
+		return channelID
+	}
+	return value
+}
@@ -0,0 +1,109 @@
+package slacker_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shomali11/slacker/v2"
+	"github.com/shomali11/slacker/v2/slackertest"
+)
+
+type deployArgs struct {
+	Service  string        `slacker:"service"`
+	Replicas int           `slacker:"replicas"`
+	Canary   *bool         `slacker:"canary"`
+	Timeout  time.Duration `slacker:"timeout"`
+	Owner    string        `slacker:"owner"`
+	Ignored  string        `slacker:"-"`
+}
+
+func TestBind(t *testing.T) {
+	harness := slackertest.NewHarness()
+	defer harness.Close()
+
+	var bound *deployArgs
+	harness.Bot().AddCommand(&slacker.CommandDefinition{
+		Command: "deploy {service} {replicas} {canary} {timeout} {owner}",
+		Parameters: []*slacker.ParameterDefinition{
+			{Name: "replicas", Type: slacker.ParameterTypeInteger},
+		},
+		Handler: slacker.TypedCommandHandler(func(ctx *slacker.CommandContext, args *deployArgs) {
+			bound = args
+		}),
+	})
+
+	harness.SendMessage("C123", "U123", "deploy api 3 true 1m30s <@U456|john>")
+
+	if bound == nil {
+		t.Fatalf("expected the handler to run, got %+v", harness.Messages())
+	}
+	if bound.Service != "api" || bound.Replicas != 3 || bound.Timeout != 90*time.Second || bound.Owner != "U456" {
+		t.Errorf("unexpected arguments %+v", bound)
+	}
+	if bound.Canary == nil || !*bound.Canary {
+		t.Errorf("expected canary to be bound, got %v", bound.Canary)
+	}
+}
+
+func TestBindInvalid(t *testing.T) {
+	harness := slackertest.NewHarness()
+	defer harness.Close()
+
+	handled := false
+	harness.Bot().AddCommand(&slacker.CommandDefinition{
+		Command: "scale {replicas}",
+		Handler: slacker.TypedCommandHandler(func(ctx *slacker.CommandContext, args *deployArgs) {
+			handled = true
+		}),
+	})
+
+	harness.SendMessage("C123", "U123", "scale many")
+
+	if handled {
+		t.Error("expected the handler not to run")
+	}
+
+	messages := harness.Messages()
+	if len(messages) != 1 || !messages[0].IsEphemeral() {
+		t.Fatalf("expected an ephemeral error reply, got %+v", messages)
+	}
+}
+
+func TestBindMissingParameters(t *testing.T) {
+	harness := slackertest.NewHarness()
+	defer harness.Close()
+
+	var bound *deployArgs
+	harness.Bot().AddCommand(&slacker.CommandDefinition{
+		Command: "status <service>",
+		Handler: slacker.TypedCommandHandler(func(ctx *slacker.CommandContext, args *deployArgs) {
+			bound = args
+		}),
+	})
+
+	harness.SendMessage("C123", "U123", "status")
+
+	if bound == nil || bound.Service != "" || bound.Canary != nil {
+		t.Errorf("expected the fields to be left untouched, got %+v", bound)
+	}
+}
+
+func TestBindInvalidTarget(t *testing.T) {
+	harness := slackertest.NewHarness()
+	defer harness.Close()
+
+	var err error
+	harness.Bot().AddCommand(&slacker.CommandDefinition{
+		Command: "status",
+		Handler: func(ctx *slacker.CommandContext) {
+			var args deployArgs
+			err = ctx.Request().Bind(args)
+		},
+	})
+
+	harness.SendMessage("C123", "U123", "status")
+
+	if err == nil {
+		t.Error("expected binding to a struct value to fail")
+	}
+}
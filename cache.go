@@ -0,0 +1,120 @@
+package slacker
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+const (
+	defaultCacheSize = 1000
+	defaultCacheTTL  = 5 * time.Minute
+	channelCacheKey  = "channel:"
+	userCacheKey     = "user:"
+)
+
+// Cache stores serialized channel and user lookups. Implement it to share
+// lookups across instances using an external store such as Redis.
+type Cache interface {
+	// Get returns the value stored for the key, if any
+	Get(key string) ([]byte, bool)
+
+	// Set stores the value for the key
+	Set(key string, value []byte)
+
+	// Delete removes the value stored for the key
+	Delete(key string)
+}
+
+// NewLRUCache creates an in-memory cache holding up to `size` entries,
+// each expiring `ttl` after being set. The least recently used entry is
+// evicted when the cache is full.
+func NewLRUCache(size int, ttl time.Duration) *LRUCache {
+	return &LRUCache{
+		size:    size,
+		ttl:     ttl,
+		entries: list.New(),
+		keys:    make(map[string]*list.Element),
+		clock:   time.Now,
+	}
+}
+
+// LRUCache is an in-memory least recently used cache with expiring entries
+type LRUCache struct {
+	size    int
+	ttl     time.Duration
+	mutex   sync.Mutex
+	entries *list.List
+	keys    map[string]*list.Element
+	clock   func() time.Time
+}
+
+// cacheEntry contains a cached value and its expiration
+type cacheEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+// Get returns the value stored for the key, if any and not expired
+func (c *LRUCache) Get(key string) ([]byte, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	element, ok := c.keys[key]
+	if !ok {
+		return nil, false
+	}
+
+	entry := element.Value.(*cacheEntry)
+	if c.clock().After(entry.expiresAt) {
+		c.remove(element)
+		return nil, false
+	}
+
+	c.entries.MoveToFront(element)
+	return entry.value, true
+}
+
+// Set stores the value for the key, evicting the least recently used entry if full
+func (c *LRUCache) Set(key string, value []byte) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	expiresAt := c.clock().Add(c.ttl)
+	if element, ok := c.keys[key]; ok {
+		entry := element.Value.(*cacheEntry)
+		entry.value = value
+		entry.expiresAt = expiresAt
+		c.entries.MoveToFront(element)
+		return
+	}
+
+	c.keys[key] = c.entries.PushFront(&cacheEntry{key: key, value: value, expiresAt: expiresAt})
+	for c.entries.Len() > c.size {
+		c.remove(c.entries.Back())
+	}
+}
+
+// Delete removes the value stored for the key
+func (c *LRUCache) Delete(key string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if element, ok := c.keys[key]; ok {
+		c.remove(element)
+	}
+}
+
+// Len returns the number of entries, including expired ones not yet evicted
+func (c *LRUCache) Len() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.entries.Len()
+}
+
+func (c *LRUCache) remove(element *list.Element) {
+	c.entries.Remove(element)
+	delete(c.keys, element.Value.(*cacheEntry).key)
+}
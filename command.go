@@ -14,6 +14,13 @@ type CommandDefinition struct {
 	Middlewares []CommandMiddlewareHandler
 	Handler     CommandHandler
 
+	// Parameters declares the types and constraints of the command's parameters.
+	// Invalid input is rejected with a usage message before the handler runs.
+	Parameters []*ParameterDefinition
+
+	// Roles restricts the command to users holding at least one of the roles
+	Roles []string
+
 	// HideHelp will hide this command definition from appearing in the `help` results.
 	HideHelp bool
 }
@@ -15,6 +15,7 @@ type CommandGroup struct {
 	prefix      string
 	middlewares []CommandMiddlewareHandler
 	commands    []Command
+	roles       []string
 }
 
 // AddMiddleware define a new middleware and append it to the list of group middlewares
@@ -22,6 +23,11 @@ func (g *CommandGroup) AddMiddleware(middleware CommandMiddlewareHandler) {
 	g.middlewares = append(g.middlewares, middleware)
 }
 
+// AddRoles restricts the group's commands to users holding at least one of the roles
+func (g *CommandGroup) AddRoles(roles ...string) {
+	g.roles = append(g.roles, roles...)
+}
+
 // AddCommand define a new command and append it to the list of group bot commands
 func (g *CommandGroup) AddCommand(definition *CommandDefinition) {
 	definition.Command = strings.TrimSpace(fmt.Sprintf("%s %s", g.prefix, definition.Command))
@@ -48,3 +54,8 @@ func (g *CommandGroup) GetCommands() []Command {
 func (g *CommandGroup) GetMiddlewares() []CommandMiddlewareHandler {
 	return g.middlewares
 }
+
+// GetRoles returns Roles
+func (g *CommandGroup) GetRoles() []string {
+	return g.roles
+}
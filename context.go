@@ -2,45 +2,72 @@ package slacker
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	"github.com/shomali11/proper"
 	"github.com/slack-go/slack"
+	"github.com/slack-go/slack/slackevents"
 )
 
 // newCommandContext creates a new command context
 func newCommandContext(
 	ctx context.Context,
-	logger Logger,
+	logger StructuredLogger,
+	metrics *metrics,
 	slackClient *slack.Client,
 	event *MessageEvent,
 	definition *CommandDefinition,
 	parameters *proper.Properties,
+	store Store,
+	conversations *conversationManager,
+	acknowledger *acknowledger,
 ) *CommandContext {
-	request := newRequest(parameters)
-	writer := newWriter(ctx, logger, slackClient)
+	logger = logger.With(logKeyChannelID, event.ChannelID, logKeyUserID, event.UserID)
+
+	var parameterDefinitions []*ParameterDefinition
+	if definition != nil {
+		parameterDefinitions = definition.Parameters
+		logger = logger.With(logKeyCommand, definition.Command)
+	}
+
+	request := newRequest(parameters, parameterDefinitions)
+	writer := newWriter(ctx, logger, metrics, slackClient)
 	replier := newReplier(event.ChannelID, event.UserID, event.InThread(), event.TimeStamp, writer)
+	if responseURL := event.ResponseURL(); len(responseURL) > 0 {
+		replier = newResponseURLReplier(event.ChannelID, event.UserID, responseURL, acknowledger, writer)
+	}
 	response := newResponseReplier(writer, replier)
 
 	return &CommandContext{
-		ctx:         ctx,
-		event:       event,
-		slackClient: slackClient,
-		definition:  definition,
-		request:     request,
-		response:    response,
-		logger:      logger,
+		ctx:           ctx,
+		writer:        writer,
+		event:         event,
+		slackClient:   slackClient,
+		definition:    definition,
+		request:       request,
+		response:      response,
+		logger:        logger,
+		store:         store,
+		conversations: conversations,
+		ack:           acknowledger,
 	}
 }
 
 // CommandContext contains information relevant to the executed command
 type CommandContext struct {
-	ctx         context.Context
-	event       *MessageEvent
-	slackClient *slack.Client
-	definition  *CommandDefinition
-	request     *Request
-	response    *ResponseReplier
-	logger      Logger
+	ctx           context.Context
+	writer        *Writer
+	event         *MessageEvent
+	slackClient   *slack.Client
+	definition    *CommandDefinition
+	request       *Request
+	response      *ResponseReplier
+	logger        StructuredLogger
+	store         Store
+	conversations *conversationManager
+	ack           *acknowledger
+	err           error
 }
 
 // Context returns the context
@@ -73,41 +100,121 @@ func (r *CommandContext) Response() *ResponseReplier {
 	return r.response
 }
 
-// Logger returns the logger
+// Logger returns a printf style logger adding the event metadata to messages.
+// It logs through the structured logger, it is not the logger set with WithLogger.
 func (r *CommandContext) Logger() Logger {
+	return newLoggerAdapter(r.logger)
+}
+
+// StructuredLogger returns the structured logger, including the event metadata
+func (r *CommandContext) StructuredLogger() StructuredLogger {
 	return r.logger
 }
 
+// Store returns the store namespaced to the command
+func (r *CommandContext) Store() *ScopedStore {
+	var command string
+	if r.definition != nil {
+		command = r.definition.Command
+	}
+	return NewScopedStore(r.store, commandStoreNamespace+command)
+}
+
+// UserStore returns the store namespaced to the user, shared by every handler
+func (r *CommandContext) UserStore() *ScopedStore {
+	return newUserStore(r.store, r.event.UserID)
+}
+
+// StartConversation starts the named conversation with the user in the thread of
+// the command message, posting the prompt of its first step. Slash commands have
+// no message to thread under, the prompt then starts the thread, and is required.
+// The values are available to every step.
+func (r *CommandContext) StartConversation(name string, values map[string]string) error {
+	threadTimeStamp := r.event.TimeStamp
+	if r.event.InThread() {
+		threadTimeStamp = r.event.ThreadTimeStamp
+	}
+	return r.conversations.start(r.ctx, r.writer, name, r.event.ChannelID, threadTimeStamp, r.event.UserID, values)
+}
+
+// Ack acknowledges a slash command with the payload, for instance a *slack.Msg
+// shown right away. Slash commands are acknowledged when their handler returns,
+// after 2.5 seconds, or before their first reply, and only the first
+// acknowledgement is sent. Other messages ignore it.
+func (r *CommandContext) Ack(payload any) {
+	r.ack.ack(payload)
+}
+
+// OpenView opens a modal view. Only slash commands carry the trigger ID needed
+// to open views, it fails for other messages.
+func (r *CommandContext) OpenView(view slack.ModalViewRequest) (*slack.ViewResponse, error) {
+	triggerID := r.event.TriggerID()
+	if len(triggerID) == 0 {
+		return nil, errors.New(missingTriggerID)
+	}
+	return r.slackClient.OpenViewContext(r.ctx, triggerID, view)
+}
+
 // newInteractionContext creates a new interaction context
 func newInteractionContext(
 	ctx context.Context,
-	logger Logger,
+	logger StructuredLogger,
+	metrics *metrics,
 	slackClient *slack.Client,
 	callback *slack.InteractionCallback,
 	definition *InteractionDefinition,
+	match *InteractionMatch,
+	store Store,
+	acknowledger *acknowledger,
 ) *InteractionContext {
+	logger = logger.With(
+		logKeyChannelID, callback.Channel.ID,
+		logKeyUserID, callback.User.ID,
+		logKeyInteractionType, callback.Type,
+	)
+	if definition != nil {
+		logger = logger.With(logKeyInteraction, definition.name())
+	}
+
+	if match == nil {
+		match = newInteractionMatch(nil)
+	}
+
+	if match.Action != nil {
+		logger = logger.With(logKeyAction, match.Action.ActionID)
+	}
+
 	inThread := isMessageInThread(callback.OriginalMessage.ThreadTimestamp, callback.OriginalMessage.Timestamp)
-	writer := newWriter(ctx, logger, slackClient)
-	replier := newReplier(callback.Channel.ID, callback.User.ID, inThread, callback.MessageTs, writer)
+	writer := newWriter(ctx, logger, metrics, slackClient)
+	replier := newInteractionReplier(interactionChannelID(callback), callback.User.ID, inThread, callback.MessageTs, writer)
 	response := newResponseReplier(writer, replier)
 	return &InteractionContext{
 		ctx:         ctx,
+		writer:      writer,
 		definition:  definition,
 		callback:    callback,
+		match:       match,
 		slackClient: slackClient,
 		response:    response,
 		logger:      logger,
+		store:       store,
+		ack:         acknowledger,
 	}
 }
 
 // InteractionContext contains information relevant to the executed interaction
 type InteractionContext struct {
 	ctx         context.Context
+	writer      *Writer
 	definition  *InteractionDefinition
 	callback    *slack.InteractionCallback
+	match       *InteractionMatch
 	slackClient *slack.Client
 	response    *ResponseReplier
-	logger      Logger
+	logger      StructuredLogger
+	store       Store
+	ack         *acknowledger
+	err         error
 }
 
 // Context returns the context
@@ -125,6 +232,23 @@ func (r *InteractionContext) Callback() *slack.InteractionCallback {
 	return r.callback
 }
 
+// Action returns the block action being handled, nil for other interaction types.
+// Each action of a block actions callback is handled separately.
+func (r *InteractionContext) Action() *slack.BlockAction {
+	return r.match.Action
+}
+
+// Captures returns the rest of the matched IDs with MatchPrefix, or the groups
+// of the regular expressions with MatchRegex
+func (r *InteractionContext) Captures() []string {
+	return r.match.Captures
+}
+
+// Capture returns the named group of the regular expressions with MatchRegex
+func (r *InteractionContext) Capture(name string) string {
+	return r.match.NamedCaptures[name]
+}
+
 // Response returns the response writer
 func (r *InteractionContext) Response() *ResponseReplier {
 	return r.response
@@ -135,38 +259,122 @@ func (r *InteractionContext) SlackClient() *slack.Client {
 	return r.slackClient
 }
 
-// Logger returns the logger
+// Logger returns a printf style logger adding the event metadata to messages.
+// It logs through the structured logger, it is not the logger set with WithLogger.
 func (r *InteractionContext) Logger() Logger {
+	return newLoggerAdapter(r.logger)
+}
+
+// StructuredLogger returns the structured logger, including the event metadata
+func (r *InteractionContext) StructuredLogger() StructuredLogger {
 	return r.logger
 }
 
+// Store returns the store namespaced to the interaction
+func (r *InteractionContext) Store() *ScopedStore {
+	var interactionID string
+	if r.definition != nil {
+		interactionID = r.definition.name()
+	}
+	return NewScopedStore(r.store, interactionStoreNamespace+interactionID)
+}
+
+// UserStore returns the store namespaced to the user, shared by every handler
+func (r *InteractionContext) UserStore() *ScopedStore {
+	return newUserStore(r.store, r.callback.User.ID)
+}
+
+// Ack acknowledges the interaction with the payload, for instance a
+// *slack.ViewSubmissionResponse updating, pushing or clearing views on view
+// submissions. Interactions not handled synchronously are acknowledged before
+// their handler runs, and only the first acknowledgement is sent.
+func (r *InteractionContext) Ack(payload any) {
+	r.ack.ack(payload)
+}
+
+// AckErrors acknowledges a view submission with errors shown under the inputs
+// with their block IDs, keeping the view open
+func (r *InteractionContext) AckErrors(viewErrors ViewErrors) {
+	r.Ack(slack.NewErrorsViewSubmissionResponse(viewErrors))
+}
+
+// BindView sets the fields of the struct pointed to by `out` from the values
+// of a view submission. Fields are tagged with `slacker:"id"`, the block ID of
+// their input. Slices receive every selected value, time.Time fields dates,
+// and inputs left empty leave their fields untouched. Values that cannot be
+// bound are returned as ViewErrors.
+func (r *InteractionContext) BindView(out any) error {
+	return bindViewValues(r.callback.View.State.Values, out)
+}
+
+// OpenView opens a modal view, using the trigger ID of the interaction
+func (r *InteractionContext) OpenView(view slack.ModalViewRequest) (*slack.ViewResponse, error) {
+	return r.slackClient.OpenViewContext(r.ctx, r.callback.TriggerID, view)
+}
+
+// PushView pushes a modal view on top of the current one, using the trigger ID of the interaction
+func (r *InteractionContext) PushView(view slack.ModalViewRequest) (*slack.ViewResponse, error) {
+	return r.slackClient.PushViewContext(r.ctx, r.callback.TriggerID, view)
+}
+
+// UpdateView replaces the view the interaction comes from. The update fails
+// if the view changed since, to avoid overwriting newer changes.
+func (r *InteractionContext) UpdateView(view slack.ModalViewRequest) (*slack.ViewResponse, error) {
+	return r.slackClient.UpdateViewContext(r.ctx, view, "", r.callback.View.Hash, r.callback.View.ID)
+}
+
 // newJobContext creates a new bot context
-func newJobContext(ctx context.Context, logger Logger, slackClient *slack.Client, definition *JobDefinition) *JobContext {
-	writer := newWriter(ctx, logger, slackClient)
+func newJobContext(
+	ctx context.Context,
+	logger StructuredLogger,
+	metrics *metrics,
+	slackClient *slack.Client,
+	definition *JobDefinition,
+	store Store,
+	attempt int,
+) *JobContext {
+	logger = logger.With(logKeyJob, definition.Name)
+	if attempt > 1 {
+		logger = logger.With(logKeyAttempt, attempt)
+	}
+
+	writer := newWriter(ctx, logger, metrics, slackClient)
 	response := newWriterResponse(writer)
 	return &JobContext{
 		ctx:         ctx,
+		writer:      writer,
 		definition:  definition,
 		slackClient: slackClient,
 		response:    response,
 		logger:      logger,
+		store:       store,
+		attempt:     attempt,
 	}
 }
 
 // JobContext contains information relevant to the executed job
 type JobContext struct {
 	ctx         context.Context
+	writer      *Writer
 	definition  *JobDefinition
 	slackClient *slack.Client
 	response    *ResponseWriter
-	logger      Logger
+	logger      StructuredLogger
+	store       Store
+	attempt     int
+	err         error
 }
 
-// Context returns the context
+// Context returns the context, canceled once the job's timeout elapses
 func (r *JobContext) Context() context.Context {
 	return r.ctx
 }
 
+// Attempt returns the attempt of the run, 1 unless the run is retried
+func (r *JobContext) Attempt() int {
+	return r.attempt
+}
+
 // Definition returns the job definition
 func (r *JobContext) Definition() *JobDefinition {
 	return r.definition
@@ -182,7 +390,636 @@ func (r *JobContext) SlackClient() *slack.Client {
 	return r.slackClient
 }
 
-// Logger returns the logger
+// Logger returns a printf style logger adding the event metadata to messages.
+// It logs through the structured logger, it is not the logger set with WithLogger.
 func (r *JobContext) Logger() Logger {
+	return newLoggerAdapter(r.logger)
+}
+
+// StructuredLogger returns the structured logger, including the event metadata
+func (r *JobContext) StructuredLogger() StructuredLogger {
+	return r.logger
+}
+
+// Store returns the store namespaced to the job
+func (r *JobContext) Store() *ScopedStore {
+	return NewScopedStore(r.store, jobStoreNamespace+r.definition.Name)
+}
+
+// UserStore returns the store namespaced to a user, shared by every handler
+func (r *JobContext) UserStore(userID string) *ScopedStore {
+	return newUserStore(r.store, userID)
+}
+
+// newEventContext creates a new event context
+func newEventContext(
+	ctx context.Context,
+	logger StructuredLogger,
+	metrics *metrics,
+	slackClient *slack.Client,
+	event *slackevents.EventsAPIEvent,
+	definition *EventDefinition,
+) *EventContext {
+	logger = logger.With(logKeyEvent, event.InnerEvent.Type)
+	if channelID, userID := eventChannelUser(event.InnerEvent.Data); len(channelID) > 0 || len(userID) > 0 {
+		logger = logger.With(logKeyChannelID, channelID, logKeyUserID, userID)
+	}
+
+	writer := newWriter(ctx, logger, metrics, slackClient)
+	response := newWriterResponse(writer)
+	return &EventContext{
+		ctx:         ctx,
+		writer:      writer,
+		definition:  definition,
+		event:       event,
+		slackClient: slackClient,
+		response:    response,
+		logger:      logger,
+	}
+}
+
+// EventContext contains information relevant to the handled event
+type EventContext struct {
+	ctx         context.Context
+	writer      *Writer
+	definition  *EventDefinition
+	event       *slackevents.EventsAPIEvent
+	slackClient *slack.Client
+	response    *ResponseWriter
+	logger      StructuredLogger
+	err         error
+}
+
+// Context returns the context
+func (r *EventContext) Context() context.Context {
+	return r.ctx
+}
+
+// Definition returns the event definition
+func (r *EventContext) Definition() *EventDefinition {
+	return r.definition
+}
+
+// Event returns the Events API event
+func (r *EventContext) Event() *slackevents.EventsAPIEvent {
+	return r.event
+}
+
+// Data returns the inner event data, such as *slackevents.ReactionAddedEvent
+func (r *EventContext) Data() any {
+	return r.event.InnerEvent.Data
+}
+
+// Response returns the response writer
+func (r *EventContext) Response() *ResponseWriter {
+	return r.response
+}
+
+// SlackClient returns the slack API client
+func (r *EventContext) SlackClient() *slack.Client {
+	return r.slackClient
+}
+
+// Logger returns a printf style logger adding the event metadata to messages.
+// It logs through the structured logger, it is not the logger set with WithLogger.
+func (r *EventContext) Logger() Logger {
+	return newLoggerAdapter(r.logger)
+}
+
+// StructuredLogger returns the structured logger, including the event metadata
+func (r *EventContext) StructuredLogger() StructuredLogger {
+	return r.logger
+}
+
+// newReactionContext creates a new reaction context
+func newReactionContext(
+	ctx context.Context,
+	logger StructuredLogger,
+	metrics *metrics,
+	slackClient *slack.Client,
+	event *slackevents.ReactionAddedEvent,
+	message *ReactionMessage,
+	definition *ReactionDefinition,
+) *ReactionContext {
+	logger = logger.With(
+		logKeyChannelID, message.ChannelID,
+		logKeyUserID, event.User,
+		logKeyReaction, normalizeEmoji(event.Reaction),
+	)
+
+	writer := newWriter(ctx, logger, metrics, slackClient)
+	replier := newReplier(message.ChannelID, event.User, true, message.threadTimeStamp(), writer)
+	response := newResponseReplier(writer, replier)
+	return &ReactionContext{
+		ctx:         ctx,
+		writer:      writer,
+		definition:  definition,
+		event:       event,
+		message:     message,
+		slackClient: slackClient,
+		response:    response,
+		logger:      logger,
+	}
+}
+
+// ReactionContext contains information relevant to the triggered reaction
+type ReactionContext struct {
+	ctx         context.Context
+	writer      *Writer
+	definition  *ReactionDefinition
+	event       *slackevents.ReactionAddedEvent
+	message     *ReactionMessage
+	slackClient *slack.Client
+	response    *ResponseReplier
+	logger      StructuredLogger
+	err         error
+}
+
+// Context returns the context
+func (r *ReactionContext) Context() context.Context {
+	return r.ctx
+}
+
+// Definition returns the reaction definition
+func (r *ReactionContext) Definition() *ReactionDefinition {
+	return r.definition
+}
+
+// Event returns the reaction added event
+func (r *ReactionContext) Event() *slackevents.ReactionAddedEvent {
+	return r.event
+}
+
+// Message returns the message the reaction was added to
+func (r *ReactionContext) Message() *ReactionMessage {
+	return r.message
+}
+
+// Response returns the response writer, replying in the thread of the message
+func (r *ReactionContext) Response() *ResponseReplier {
+	return r.response
+}
+
+// SlackClient returns the slack API client
+func (r *ReactionContext) SlackClient() *slack.Client {
+	return r.slackClient
+}
+
+// Logger returns a printf style logger adding the event metadata to messages.
+// It logs through the structured logger, it is not the logger set with WithLogger.
+func (r *ReactionContext) Logger() Logger {
+	return newLoggerAdapter(r.logger)
+}
+
+// StructuredLogger returns the structured logger, including the event metadata
+func (r *ReactionContext) StructuredLogger() StructuredLogger {
+	return r.logger
+}
+
+// newAppHomeContext creates a new App Home context
+func newAppHomeContext(
+	ctx context.Context,
+	logger StructuredLogger,
+	metrics *metrics,
+	slackClient *slack.Client,
+	userID string,
+	event *slackevents.AppHomeOpenedEvent,
+	definition *AppHomeDefinition,
+) *AppHomeContext {
+	logger = logger.With(logKeyUserID, userID)
+	writer := newWriter(ctx, logger, metrics, slackClient)
+	response := newWriterResponse(writer)
+	return &AppHomeContext{
+		ctx:         ctx,
+		writer:      writer,
+		definition:  definition,
+		userID:      userID,
+		event:       event,
+		slackClient: slackClient,
+		response:    response,
+		logger:      logger,
+	}
+}
+
+// AppHomeContext contains information relevant to the rendered App Home tab
+type AppHomeContext struct {
+	ctx         context.Context
+	writer      *Writer
+	definition  *AppHomeDefinition
+	userID      string
+	event       *slackevents.AppHomeOpenedEvent
+	view        *slack.HomeTabViewRequest
+	slackClient *slack.Client
+	response    *ResponseWriter
+	logger      StructuredLogger
+	err         error
+}
+
+// Context returns the context
+func (r *AppHomeContext) Context() context.Context {
+	return r.ctx
+}
+
+// Definition returns the App Home definition
+func (r *AppHomeContext) Definition() *AppHomeDefinition {
+	return r.definition
+}
+
+// UserID returns the ID of the user the home view is rendered for
+func (r *AppHomeContext) UserID() string {
+	return r.userID
+}
+
+// Event returns the app home opened event, nil if the home view is republished
+func (r *AppHomeContext) Event() *slackevents.AppHomeOpenedEvent {
+	return r.event
+}
+
+// Render sets the home view to publish for the user
+func (r *AppHomeContext) Render(view slack.HomeTabViewRequest) {
+	view.Type = slack.VTHomeTab
+	r.view = &view
+}
+
+// View returns the rendered home view, nil if none was rendered yet
+func (r *AppHomeContext) View() *slack.HomeTabViewRequest {
+	return r.view
+}
+
+// Response returns the response writer
+func (r *AppHomeContext) Response() *ResponseWriter {
+	return r.response
+}
+
+// SlackClient returns the slack API client
+func (r *AppHomeContext) SlackClient() *slack.Client {
+	return r.slackClient
+}
+
+// Logger returns a printf style logger adding the event metadata to messages.
+// It logs through the structured logger, it is not the logger set with WithLogger.
+func (r *AppHomeContext) Logger() Logger {
+	return newLoggerAdapter(r.logger)
+}
+
+// StructuredLogger returns the structured logger, including the event metadata
+func (r *AppHomeContext) StructuredLogger() StructuredLogger {
+	return r.logger
+}
+
+// newConversationContext creates a new conversation context
+func newConversationContext(
+	ctx context.Context,
+	logger StructuredLogger,
+	metrics *metrics,
+	slackClient *slack.Client,
+	event *MessageEvent,
+	state *ConversationState,
+	definition *ConversationDefinition,
+) *ConversationContext {
+	logger = logger.With(
+		logKeyChannelID, event.ChannelID,
+		logKeyUserID, event.UserID,
+		logKeyConversation, state.Name,
+		logKeyStep, state.Step,
+	)
+
+	if state.Values == nil {
+		state.Values = make(map[string]string)
+	}
+
+	writer := newWriter(ctx, logger, metrics, slackClient)
+	replier := newReplier(event.ChannelID, event.UserID, true, state.ThreadTimeStamp, writer)
+	response := newResponseReplier(writer, replier)
+	return &ConversationContext{
+		ctx:         ctx,
+		writer:      writer,
+		definition:  definition,
+		event:       event,
+		state:       state,
+		step:        state.Step,
+		slackClient: slackClient,
+		response:    response,
+		logger:      logger,
+	}
+}
+
+// ConversationContext contains information relevant to the handled conversation reply
+type ConversationContext struct {
+	ctx         context.Context
+	writer      *Writer
+	definition  *ConversationDefinition
+	event       *MessageEvent
+	state       *ConversationState
+	step        string
+	ended       bool
+	slackClient *slack.Client
+	response    *ResponseReplier
+	logger      StructuredLogger
+	err         error
+}
+
+// Context returns the context
+func (r *ConversationContext) Context() context.Context {
+	return r.ctx
+}
+
+// Definition returns the conversation definition
+func (r *ConversationContext) Definition() *ConversationDefinition {
+	return r.definition
+}
+
+// Event returns the slack message event of the reply
+func (r *ConversationContext) Event() *MessageEvent {
+	return r.event
+}
+
+// Step returns the name of the step handling the reply
+func (r *ConversationContext) Step() string {
+	return r.state.Step
+}
+
+// Value returns the value stored for the key by a previous step, if any
+func (r *ConversationContext) Value(key string) string {
+	return r.state.Values[key]
+}
+
+// Values returns every value stored so far
+func (r *ConversationContext) Values() map[string]string {
+	return r.state.Values
+}
+
+// SetValue stores a value, available to the next steps
+func (r *ConversationContext) SetValue(key string, value string) {
+	r.state.Values[key] = value
+}
+
+// Next moves the conversation to the step once the handler returns, posting its prompt
+func (r *ConversationContext) Next(step string) {
+	r.step = step
+}
+
+// End ends the conversation once the handler returns
+func (r *ConversationContext) End() {
+	r.ended = true
+}
+
+// Response returns the response writer, replying in the thread of the conversation
+func (r *ConversationContext) Response() *ResponseReplier {
+	return r.response
+}
+
+// SlackClient returns the slack API client
+func (r *ConversationContext) SlackClient() *slack.Client {
+	return r.slackClient
+}
+
+// Logger returns a printf style logger adding the event metadata to messages.
+// It logs through the structured logger, it is not the logger set with WithLogger.
+func (r *ConversationContext) Logger() Logger {
+	return newLoggerAdapter(r.logger)
+}
+
+// StructuredLogger returns the structured logger, including the event metadata
+func (r *ConversationContext) StructuredLogger() StructuredLogger {
+	return r.logger
+}
+
+// newOptionsContext creates a new options context
+func newOptionsContext(
+	ctx context.Context,
+	logger StructuredLogger,
+	metrics *metrics,
+	slackClient *slack.Client,
+	callback *slack.InteractionCallback,
+	definition *OptionsDefinition,
+	match *InteractionMatch,
+) *OptionsContext {
+	logger = logger.With(
+		logKeyChannelID, callback.Channel.ID,
+		logKeyUserID, callback.User.ID,
+		logKeyAction, callback.ActionID,
+	)
+	writer := newWriter(ctx, logger, metrics, slackClient)
+	response := newWriterResponse(writer)
+	return &OptionsContext{
+		ctx:         ctx,
+		writer:      writer,
+		definition:  definition,
+		callback:    callback,
+		query:       newOptionsQuery(callback),
+		match:       match,
+		slackClient: slackClient,
+		response:    response,
+		logger:      logger,
+	}
+}
+
+// OptionsContext contains information relevant to the suggested options
+type OptionsContext struct {
+	ctx          context.Context
+	writer       *Writer
+	definition   *OptionsDefinition
+	callback     *slack.InteractionCallback
+	query        *OptionsQuery
+	match        *InteractionMatch
+	options      []*slack.OptionBlockObject
+	optionGroups []*slack.OptionGroupBlockObject
+	slackClient  *slack.Client
+	response     *ResponseWriter
+	logger       StructuredLogger
+	err          error
+}
+
+// Context returns the context
+func (r *OptionsContext) Context() context.Context {
+	return r.ctx
+}
+
+// Definition returns the options definition
+func (r *OptionsContext) Definition() *OptionsDefinition {
+	return r.definition
+}
+
+// Callback returns the block suggestion callback
+func (r *OptionsContext) Callback() *slack.InteractionCallback {
+	return r.callback
+}
+
+// Query returns what the user typed, and where
+func (r *OptionsContext) Query() *OptionsQuery {
+	return r.query
+}
+
+// Captures returns the rest of the matched IDs with MatchPrefix, or the groups
+// of the regular expressions with MatchRegex
+func (r *OptionsContext) Captures() []string {
+	return r.match.Captures
+}
+
+// Capture returns the named group of the regular expressions with MatchRegex
+func (r *OptionsContext) Capture(name string) string {
+	return r.match.NamedCaptures[name]
+}
+
+// Respond sets the options suggested to the user, up to 100
+func (r *OptionsContext) Respond(options ...*slack.OptionBlockObject) {
+	r.options = options
+	r.optionGroups = nil
+}
+
+// RespondGroups sets the option groups suggested to the user, replacing the options
+func (r *OptionsContext) RespondGroups(optionGroups ...*slack.OptionGroupBlockObject) {
+	r.optionGroups = optionGroups
+	r.options = nil
+}
+
+// Response returns the response writer
+func (r *OptionsContext) Response() *ResponseWriter {
+	return r.response
+}
+
+// SlackClient returns the slack API client
+func (r *OptionsContext) SlackClient() *slack.Client {
+	return r.slackClient
+}
+
+// Logger returns a printf style logger adding the event metadata to messages.
+// It logs through the structured logger, it is not the logger set with WithLogger.
+func (r *OptionsContext) Logger() Logger {
+	return newLoggerAdapter(r.logger)
+}
+
+// StructuredLogger returns the structured logger, including the event metadata
+func (r *OptionsContext) StructuredLogger() StructuredLogger {
+	return r.logger
+}
+
+// payload returns the acknowledgement carrying the suggested options, none if the handler failed
+func (r *OptionsContext) payload() any {
+	if r.err != nil {
+		return &slack.OptionsResponse{Options: []*slack.OptionBlockObject{}}
+	}
+
+	if r.optionGroups != nil {
+		return &slack.OptionGroupsResponse{OptionGroups: r.optionGroups}
+	}
+
+	options := r.options
+	if options == nil {
+		options = []*slack.OptionBlockObject{}
+	}
+	return &slack.OptionsResponse{Options: options}
+}
+
+// newFunctionContext creates a new function context
+func newFunctionContext(
+	ctx context.Context,
+	logger StructuredLogger,
+	slackClient *slack.Client,
+	writer *Writer,
+	event *FunctionExecutedEvent,
+	definition *FunctionDefinition,
+) *FunctionContext {
+	response := newWriterResponse(writer)
+	return &FunctionContext{
+		ctx:         ctx,
+		writer:      writer,
+		definition:  definition,
+		event:       event,
+		slackClient: slackClient,
+		response:    response,
+		logger:      logger,
+	}
+}
+
+// FunctionContext contains information relevant to the executed Workflow Builder custom step
+type FunctionContext struct {
+	ctx         context.Context
+	writer      *Writer
+	definition  *FunctionDefinition
+	event       *FunctionExecutedEvent
+	slackClient *slack.Client
+	response    *ResponseWriter
+	logger      StructuredLogger
+	completed   bool
+	err         error
+}
+
+// Context returns the context
+func (r *FunctionContext) Context() context.Context {
+	return r.ctx
+}
+
+// Definition returns the function definition
+func (r *FunctionContext) Definition() *FunctionDefinition {
+	return r.definition
+}
+
+// Event returns the function executed event
+func (r *FunctionContext) Event() *FunctionExecutedEvent {
+	return r.event
+}
+
+// ExecutionID returns the ID of the run, to complete it later with Slacker.CompleteFunction
+func (r *FunctionContext) ExecutionID() string {
+	return r.event.FunctionExecutionID
+}
+
+// Inputs returns the values of the step's input parameters, keyed by name
+func (r *FunctionContext) Inputs() map[string]any {
+	return r.event.Inputs
+}
+
+// Input returns the value of the input parameter formatted as a string, empty if missing
+func (r *FunctionContext) Input(name string) string {
+	value, ok := r.event.Inputs[name]
+	if !ok || value == nil {
+		return ""
+	}
+
+	if text, ok := value.(string); ok {
+		return text
+	}
+	return fmt.Sprint(value)
+}
+
+// BindInputs sets the fields of the struct pointed to by `out` from the input
+// parameters. Fields are tagged with `slacker:"name"`, the name of their input,
+// and receive the values as JSON would. Timestamps are bound to time.Time fields.
+func (r *FunctionContext) BindInputs(out any) error {
+	return bindFunctionInputs(r.event.Inputs, out)
+}
+
+// Complete completes the step with its outputs, keyed by name
+func (r *FunctionContext) Complete(outputs map[string]any) error {
+	r.completed = true
+	return r.writer.CompleteFunction(r.event.FunctionExecutionID, outputs)
+}
+
+// Fail fails the step, showing the message in the workflow's activity
+func (r *FunctionContext) Fail(message string) error {
+	r.completed = true
+	return r.writer.FailFunction(r.event.FunctionExecutionID, message)
+}
+
+// Response returns the response writer
+func (r *FunctionContext) Response() *ResponseWriter {
+	return r.response
+}
+
+// SlackClient returns the slack API client
+func (r *FunctionContext) SlackClient() *slack.Client {
+	return r.slackClient
+}
+
+// Logger returns a printf style logger adding the event metadata to messages.
+// It logs through the structured logger, it is not the logger set with WithLogger.
+func (r *FunctionContext) Logger() Logger {
+	return newLoggerAdapter(r.logger)
+}
+
+// StructuredLogger returns the structured logger, including the event metadata
+func (r *FunctionContext) StructuredLogger() StructuredLogger {
 	return r.logger
 }
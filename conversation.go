@@ -0,0 +1,292 @@
+package slacker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultConversationTimeout = 10 * time.Minute
+	defaultCancelMessage       = "Conversation cancelled"
+	defaultTimeoutMessage      = "Conversation timed out, please start over"
+	conversationKeyFormat      = "%s:%s:%s"
+	conversationNotFound       = "conversation not found"
+	conversationStepNotFound   = "conversation step not found"
+	conversationThreadMissing  = "conversation without message to thread under needs a prompt to start the thread"
+	conversationSweepInterval  = time.Minute
+)
+
+var defaultCancelKeywords = []string{"cancel"}
+
+// ConversationDefinition structure contains definition of a multi-step conversation.
+// Once started from a command, the replies of the user in the thread of the
+// command are handled by the current step instead of being matched to commands.
+type ConversationDefinition struct {
+	// Name identifies the conversation, used to start it
+	Name string
+
+	// Steps handle the replies of the user, starting with the first one
+	Steps []*ConversationStep
+
+	// Timeout is how long to wait for the next reply, 10 minutes by default
+	Timeout time.Duration
+
+	// CancelKeywords end the conversation when replied, case insensitive. Defaults to `cancel`.
+	CancelKeywords []string
+
+	// CancelMessage is replied when the user cancels the conversation
+	CancelMessage string
+
+	// TimeoutMessage is replied when the user replies after the conversation timed out
+	TimeoutMessage string
+
+	Middlewares []ConversationMiddlewareHandler
+}
+
+// ConversationStep structure contains a step of a conversation
+type ConversationStep struct {
+	// Name identifies the step, used to move to it with ConversationContext.Next
+	Name string
+
+	// Prompt is posted in the thread when the conversation moves to the step
+	Prompt string
+
+	// Handler handles the reply of the user. It stays on the same step unless it
+	// calls ConversationContext.Next or ConversationContext.End.
+	Handler ConversationHandler
+}
+
+// newConversation creates a new bot conversation object
+func newConversation(definition *ConversationDefinition) *Conversation {
+	if definition.Timeout <= 0 {
+		definition.Timeout = defaultConversationTimeout
+	}
+
+	if definition.CancelKeywords == nil {
+		definition.CancelKeywords = defaultCancelKeywords
+	}
+
+	if len(definition.CancelMessage) == 0 {
+		definition.CancelMessage = defaultCancelMessage
+	}
+
+	if len(definition.TimeoutMessage) == 0 {
+		definition.TimeoutMessage = defaultTimeoutMessage
+	}
+
+	return &Conversation{definition: definition}
+}
+
+// Conversation structure contains the bot's conversation and its steps
+type Conversation struct {
+	definition *ConversationDefinition
+}
+
+// Definition returns the conversation definition
+func (c *Conversation) Definition() *ConversationDefinition {
+	return c.definition
+}
+
+// IsCancel determines whether the text cancels the conversation
+func (c *Conversation) IsCancel(text string) bool {
+	text = strings.TrimSpace(text)
+	for _, keyword := range c.definition.CancelKeywords {
+		if strings.EqualFold(text, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// step returns the step with the name, if any
+func (c *Conversation) step(name string) *ConversationStep {
+	for _, step := range c.definition.Steps {
+		if step.Name == name {
+			return step
+		}
+	}
+	return nil
+}
+
+// ConversationState contains the progress of a conversation with a user.
+// It is serializable to JSON, for stores persisting it outside of the process.
+type ConversationState struct {
+	Name            string            `json:"name"`
+	Step            string            `json:"step"`
+	ChannelID       string            `json:"channel_id"`
+	ThreadTimeStamp string            `json:"thread_ts"`
+	UserID          string            `json:"user_id"`
+	Values          map[string]string `json:"values"`
+	ExpiresAt       time.Time         `json:"expires_at"`
+}
+
+// clone returns a copy of the state, not sharing its values
+func (s *ConversationState) clone() *ConversationState {
+	state := *s
+	state.Values = make(map[string]string, len(s.Values))
+	for key, value := range s.Values {
+		state.Values[key] = value
+	}
+	return &state
+}
+
+// ConversationStore persists the state of ongoing conversations. Implement it to
+// share conversations across instances using an external store such as Redis.
+type ConversationStore interface {
+	// Get returns the state stored for the key, if any
+	Get(ctx context.Context, key string) (*ConversationState, bool, error)
+
+	// Set stores the state for the key
+	Set(ctx context.Context, key string, state *ConversationState) error
+
+	// Delete removes the state stored for the key
+	Delete(ctx context.Context, key string) error
+}
+
+// NewMemoryConversationStore creates an in-memory conversation store
+func NewMemoryConversationStore() *MemoryConversationStore {
+	return &MemoryConversationStore{
+		states: make(map[string]*ConversationState),
+		clock:  time.Now,
+	}
+}
+
+// MemoryConversationStore is an in-memory conversation store. States expired
+// for a while are removed as new ones are stored, so abandoned conversations
+// do not accumulate.
+type MemoryConversationStore struct {
+	mutex     sync.Mutex
+	states    map[string]*ConversationState
+	clock     func() time.Time
+	lastSweep time.Time
+}
+
+// Get returns the state stored for the key, if any
+func (s *MemoryConversationStore) Get(_ context.Context, key string) (*ConversationState, bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	state, ok := s.states[key]
+	if !ok {
+		return nil, false, nil
+	}
+	return state.clone(), true, nil
+}
+
+// Set stores the state for the key
+func (s *MemoryConversationStore) Set(_ context.Context, key string, state *ConversationState) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.states[key] = state.clone()
+	s.sweep()
+	return nil
+}
+
+// sweep removes the states expired for more than the sweep interval, at most
+// once per interval. Recently expired states are kept, for late replies to be
+// told the conversation timed out.
+func (s *MemoryConversationStore) sweep() {
+	now := s.clock()
+	if now.Sub(s.lastSweep) < conversationSweepInterval {
+		return
+	}
+	s.lastSweep = now
+
+	for key, state := range s.states {
+		if now.Sub(state.ExpiresAt) > conversationSweepInterval {
+			delete(s.states, key)
+		}
+	}
+}
+
+// Delete removes the state stored for the key
+func (s *MemoryConversationStore) Delete(_ context.Context, key string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	delete(s.states, key)
+	return nil
+}
+
+// conversationKey returns the store key of the conversation with a user in a thread
+func conversationKey(channelID string, threadTimeStamp string, userID string) string {
+	return fmt.Sprintf(conversationKeyFormat, channelID, threadTimeStamp, userID)
+}
+
+// newConversationManager creates a new conversation manager structure
+func newConversationManager(store ConversationStore) *conversationManager {
+	return &conversationManager{
+		store:         store,
+		conversations: make(map[string]*Conversation),
+		clock:         time.Now,
+	}
+}
+
+// conversationManager starts conversations and finds the ones replies belong to
+type conversationManager struct {
+	store         ConversationStore
+	conversations map[string]*Conversation
+	clock         func() time.Time
+}
+
+// start stores the state of a new conversation and posts the prompt of its first step in the thread
+func (m *conversationManager) start(
+	ctx context.Context,
+	writer *Writer,
+	name string,
+	channelID string,
+	threadTimeStamp string,
+	userID string,
+	values map[string]string,
+) error {
+	conversation, ok := m.conversations[name]
+	if !ok {
+		return errors.New(conversationNotFound)
+	}
+
+	definition := conversation.Definition()
+	prompt := definition.Steps[0].Prompt
+
+	// Slash commands carry no message to reply in the thread of, the prompt of
+	// the first step starts the thread instead
+	startsThread := len(threadTimeStamp) == 0
+	if startsThread {
+		if len(prompt) == 0 {
+			return errors.New(conversationThreadMissing)
+		}
+
+		var err error
+		threadTimeStamp, err = writer.Post(channelID, prompt)
+		if err != nil {
+			return err
+		}
+	}
+
+	state := &ConversationState{
+		Name:            name,
+		Step:            definition.Steps[0].Name,
+		ChannelID:       channelID,
+		ThreadTimeStamp: threadTimeStamp,
+		UserID:          userID,
+		Values:          values,
+		ExpiresAt:       m.clock().Add(definition.Timeout),
+	}
+	if state.Values == nil {
+		state.Values = make(map[string]string)
+	}
+
+	err := m.store.Set(ctx, conversationKey(channelID, threadTimeStamp, userID), state)
+	if err != nil || startsThread {
+		return err
+	}
+
+	if len(prompt) > 0 {
+		_, err = writer.Post(channelID, prompt, SetThreadTS(threadTimeStamp))
+	}
+	return err
+}
@@ -0,0 +1,88 @@
+package slacker_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shomali11/slacker/v2"
+	"github.com/shomali11/slacker/v2/slackertest"
+)
+
+func TestStartConversationFromSlashCommand(t *testing.T) {
+	harness := slackertest.NewHarness()
+	defer harness.Close()
+
+	var answer string
+	harness.Bot().AddConversation(&slacker.ConversationDefinition{
+		Name: "survey",
+		Steps: []*slacker.ConversationStep{
+			{
+				Name:   "color",
+				Prompt: "Favorite color?",
+				Handler: func(ctx *slacker.ConversationContext) {
+					answer = ctx.Event().Text
+					ctx.End()
+				},
+			},
+		},
+	})
+	harness.Bot().AddCommand(&slacker.CommandDefinition{
+		Command: "survey",
+		Handler: func(ctx *slacker.CommandContext) {
+			if err := ctx.StartConversation("survey", nil); err != nil {
+				t.Errorf("unexpected error %v", err)
+			}
+		},
+	})
+
+	harness.SendSlashCommand("C123", "U123", "/survey", "")
+
+	messages := harness.Messages()
+	if len(messages) != 1 || messages[0].Text != "Favorite color?" || len(messages[0].ThreadTimeStamp) > 0 {
+		t.Fatalf("expected the prompt to start a thread, got %+v", messages)
+	}
+
+	harness.SendThreadMessage("C123", "U123", messages[0].TimeStamp, "blue")
+	if answer != "blue" {
+		t.Errorf("expected the reply in the thread of the prompt to continue the conversation, got %q", answer)
+	}
+}
+
+func TestStartConversationFromSlashCommandWithoutPrompt(t *testing.T) {
+	harness := slackertest.NewHarness()
+	defer harness.Close()
+
+	harness.Bot().AddConversation(&slacker.ConversationDefinition{
+		Name:  "silent",
+		Steps: []*slacker.ConversationStep{{Name: "first", Handler: func(*slacker.ConversationContext) {}}},
+	})
+
+	var err error
+	harness.Bot().AddCommand(&slacker.CommandDefinition{
+		Command: "silent",
+		Handler: func(ctx *slacker.CommandContext) {
+			err = ctx.StartConversation("silent", nil)
+		},
+	})
+
+	harness.SendSlashCommand("C123", "U123", "/silent", "")
+	if err == nil {
+		t.Error("expected an error starting a conversation without thread nor prompt")
+	}
+}
+
+func TestMemoryConversationStoreSweepsExpiredStates(t *testing.T) {
+	ctx := context.Background()
+	store := slacker.NewMemoryConversationStore()
+
+	store.Set(ctx, "abandoned", &slacker.ConversationState{ExpiresAt: time.Now().Add(-time.Hour)})
+	store.Set(ctx, "recent", &slacker.ConversationState{ExpiresAt: time.Now().Add(-time.Second)})
+
+	if _, ok, _ := store.Get(ctx, "abandoned"); ok {
+		t.Error("expected the abandoned state to be swept")
+	}
+	if _, ok, _ := store.Get(ctx, "recent"); !ok {
+		t.Error("expected the recently expired state to be kept")
+	}
+}
@@ -0,0 +1,308 @@
+package slacker
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/slack-go/slack"
+	"github.com/slack-go/slack/slackevents"
+	"github.com/slack-go/slack/socketmode"
+)
+
+const (
+	defaultShutdownTimeout = 5 * time.Second
+	jobTaskPrefix          = "job "
+)
+
+// newDispatcher creates a new dispatcher structure. Handlers run on the pool
+// when provided, otherwise each in its own goroutine.
+func newDispatcher(logger StructuredLogger, pool *workerPool) *dispatcher {
+	return &dispatcher{
+		logger:   logger,
+		pool:     pool,
+		ctx:      context.Background(),
+		cancel:   func() {},
+		inFlight: make(map[int]string),
+	}
+}
+
+// dispatcher runs handlers while keeping track of the ones in flight so that
+// they can be drained on shutdown
+type dispatcher struct {
+	logger   StructuredLogger
+	pool     *workerPool
+	mutex    sync.Mutex
+	ctx      context.Context
+	cancel   context.CancelFunc
+	closed   bool
+	waiter   sync.WaitGroup
+	nextID   int
+	inFlight map[int]string
+}
+
+// start derives the handlers' context from ctx. Handlers keep running once ctx
+// is done, until they complete or the shutdown timeout elapses.
+func (d *dispatcher) start(ctx context.Context) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.ctx, d.cancel = context.WithCancel(detachedContext{parent: ctx})
+	d.closed = false
+
+	if d.pool != nil {
+		d.pool.start()
+	}
+}
+
+// context returns the context handlers should run with
+func (d *dispatcher) context() context.Context {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.ctx
+}
+
+// task contains a handler to dispatch
+type task struct {
+	// name describes the task when logged
+	name string
+
+	// key identifies the conversation, usually the channel, the task is
+	// serialized on when the worker pool serializes tasks
+	key string
+
+	// handler processes the event
+	handler func(ctx context.Context)
+
+	// busy lets the user know the task was discarded, it can be nil
+	busy func(ctx context.Context)
+}
+
+// dispatch runs the task asynchronously, unless shutting down
+func (d *dispatcher) dispatch(t *task) {
+	id, ctx, ok := d.track(t.name)
+	if !ok {
+		d.logger.Debug("shutting down, ignored handler", "handler", t.name)
+		return
+	}
+
+	if d.pool == nil {
+		go func() {
+			defer d.untrack(id)
+			t.handler(ctx)
+		}()
+		return
+	}
+
+	d.pool.submit(&work{
+		key: t.key,
+		run: func() {
+			defer d.untrack(id)
+			t.handler(ctx)
+		},
+		drop: func(busy bool) {
+			defer d.untrack(id)
+			d.logger.Debug("discarded handler", "handler", t.name)
+
+			if busy && t.busy != nil {
+				t.busy(ctx)
+			}
+		},
+	})
+}
+
+// run runs the handler in the current goroutine, unless shutting down
+func (d *dispatcher) run(name string, handler func(ctx context.Context)) {
+	id, ctx, ok := d.track(name)
+	if !ok {
+		d.logger.Debug("shutting down, ignored handler", "handler", name)
+		return
+	}
+
+	defer d.untrack(id)
+	handler(ctx)
+}
+
+// shutdown stops accepting handlers and waits for the ones in flight, and for
+// the scheduled job runs to be done, up to the timeout. Their context is then
+// canceled and whatever still runs is reported as abandoned, once.
+func (d *dispatcher) shutdown(timeout time.Duration, jobsDone <-chan struct{}) {
+	d.mutex.Lock()
+	d.closed = true
+	d.mutex.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		d.waiter.Wait()
+		<-jobsDone
+		close(done)
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	jobsRunning := false
+	select {
+	case <-done:
+	case <-timer.C:
+		select {
+		case <-jobsDone:
+		default:
+			jobsRunning = true
+		}
+	}
+
+	if d.pool != nil {
+		d.pool.stop()
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.cancel()
+
+	if len(d.inFlight) == 0 && !jobsRunning {
+		return
+	}
+
+	names := make([]string, 0, len(d.inFlight)+1)
+	jobsInFlight := false
+	for _, name := range d.inFlight {
+		names = append(names, name)
+		jobsInFlight = jobsInFlight || strings.HasPrefix(name, jobTaskPrefix)
+	}
+	sort.Strings(names)
+
+	if jobsRunning && !jobsInFlight {
+		// Scheduled runs waiting for their previous run to complete
+		names = append(names, "scheduled jobs")
+	}
+
+	d.logger.Error("abandoned in-flight handlers", "timeout", timeout, "handlers", strings.Join(names, ", "))
+}
+
+func (d *dispatcher) track(name string) (int, context.Context, bool) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.closed {
+		return 0, nil, false
+	}
+
+	d.nextID++
+	d.inFlight[d.nextID] = name
+	d.waiter.Add(1)
+	return d.nextID, d.ctx, true
+}
+
+func (d *dispatcher) untrack(id int) {
+	d.mutex.Lock()
+	delete(d.inFlight, id)
+	d.mutex.Unlock()
+
+	d.waiter.Done()
+}
+
+// dispatchEventsAPIEvent dispatches an Events API event, serialized on its channel
+func (s *Slacker) dispatchEventsAPIEvent(socketEvent socketmode.Event, event slackevents.EventsAPIEvent) {
+	channelID, eventUserID := eventChannelUser(event.InnerEvent.Data)
+
+	// Only reply busy to messages addressed to the bot
+	var userID string
+	switch data := event.InnerEvent.Data.(type) {
+	case *slackevents.MessageEvent:
+		if data.ChannelType == slack.TYPE_IM {
+			userID = eventUserID
+		}
+	case *slackevents.AppMentionEvent:
+		userID = eventUserID
+	}
+
+	name := "event " + event.InnerEvent.Type
+	s.dispatcher.dispatch(&task{
+		name: name,
+		key:  channelID,
+		handler: s.traceRoot(name, func(ctx context.Context) {
+			s.handleEventsAPIEvent(ctx, socketEvent, event)
+		}),
+		busy: s.replyBusy(channelID, userID),
+	})
+}
+
+// dispatchSlashCommand dispatches a slash command, serialized on its channel
+func (s *Slacker) dispatchSlashCommand(event slack.SlashCommand, acknowledger *acknowledger) {
+	name := "slash command " + event.Command
+	s.dispatcher.dispatch(&task{
+		name: name,
+		key:  event.ChannelID,
+		handler: s.traceRoot(name, func(ctx context.Context) {
+			s.handleMessageEvent(ctx, &event, acknowledger)
+		}),
+		busy: s.replyBusy(event.ChannelID, event.UserID),
+	})
+}
+
+// dispatchInteraction dispatches an interaction, serialized on its channel.
+// The acknowledger is nil if the interaction was already acknowledged.
+func (s *Slacker) dispatchInteraction(callback slack.InteractionCallback, acknowledger *acknowledger) {
+	name := "interaction " + string(callback.Type)
+	busy := s.replyBusy(callback.Channel.ID, callback.User.ID)
+	if callback.Type == slack.InteractionTypeBlockSuggestion {
+		// Suggestions are requested as the user types, they are dropped silently
+		busy = nil
+	}
+
+	s.dispatcher.dispatch(&task{
+		name: name,
+		key:  callback.Channel.ID,
+		handler: s.traceRoot(name, func(ctx context.Context) {
+			s.handleInteractionEvent(ctx, &callback, acknowledger)
+		}),
+		busy: busy,
+	})
+}
+
+// replyBusy returns a function letting the user know the bot is busy, or nil
+// if there is nobody to reply to
+func (s *Slacker) replyBusy(channelID string, userID string) func(ctx context.Context) {
+	if len(channelID) == 0 || len(userID) == 0 {
+		return nil
+	}
+
+	return func(ctx context.Context) {
+		go func() {
+			writer := newWriter(ctx, s.logger, s.metrics, s.slackClient)
+			if _, err := writer.Post(channelID, s.busyMessage, SetEphemeral(userID)); err != nil {
+				s.logger.Error("failed to reply busy", logKeyError, err)
+			}
+		}()
+	}
+}
+
+// detachedContext keeps the values of its parent without being canceled along with it
+type detachedContext struct {
+	parent context.Context
+}
+
+// Deadline returns no deadline
+func (c detachedContext) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+// Done returns a nil channel as the context is never canceled
+func (c detachedContext) Done() <-chan struct{} {
+	return nil
+}
+
+// Err returns nil as the context is never canceled
+func (c detachedContext) Err() error {
+	return nil
+}
+
+// Value returns the parent's value associated with the key
+func (c detachedContext) Value(key any) any {
+	return c.parent.Value(key)
+}
@@ -0,0 +1,329 @@
+package slacker
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+)
+
+const (
+	defaultPanicMessage = "something went wrong, please try again later"
+)
+
+// ContextType represents the kind of handler that failed
+type ContextType int
+
+const (
+	// ContextTypeCommand is a command handler
+	ContextTypeCommand ContextType = iota
+
+	// ContextTypeInteraction is an interaction handler
+	ContextTypeInteraction
+
+	// ContextTypeJob is a job handler
+	ContextTypeJob
+
+	// ContextTypeEvent is an event handler
+	ContextTypeEvent
+
+	// ContextTypeReaction is a reaction handler
+	ContextTypeReaction
+
+	// ContextTypeAppHome is the App Home handler
+	ContextTypeAppHome
+
+	// ContextTypeConversation is a conversation step handler
+	ContextTypeConversation
+
+	// ContextTypeOptions is an options handler of external selects
+	ContextTypeOptions
+
+	// ContextTypeFunction is a Workflow Builder custom step handler
+	ContextTypeFunction
+)
+
+// String returns the name of the context type
+func (t ContextType) String() string {
+	switch t {
+	case ContextTypeCommand:
+		return "command"
+	case ContextTypeInteraction:
+		return "interaction"
+	case ContextTypeJob:
+		return "job"
+	case ContextTypeEvent:
+		return "event"
+	case ContextTypeReaction:
+		return "reaction"
+	case ContextTypeAppHome:
+		return "app_home"
+	case ContextTypeConversation:
+		return "conversation"
+	case ContextTypeOptions:
+		return "options"
+	case ContextTypeFunction:
+		return "function"
+	default:
+		return "unknown"
+	}
+}
+
+// HandlerError describes an error returned, or a panic raised, by a handler
+type HandlerError struct {
+	// ContextType is the kind of handler that failed
+	ContextType ContextType
+
+	// Context is the *CommandContext, *InteractionContext, *JobContext,
+	// *EventContext, *ReactionContext, *AppHomeContext, *ConversationContext,
+	// *OptionsContext or *FunctionContext of the handler
+	Context any
+
+	// Definition is the *CommandDefinition, *InteractionDefinition,
+	// *JobDefinition, *EventDefinition, *ReactionDefinition, *AppHomeDefinition,
+	// *ConversationDefinition, *OptionsDefinition or *FunctionDefinition of the
+	// handler. It is nil for the unsupported command and interaction handlers.
+	Definition any
+
+	// Err is the error returned by the handler, or describes the recovered value
+	Err error
+
+	// Recovered is the value the handler panicked with, nil if it returned an error
+	Recovered any
+
+	// Stack is the stack trace of the panic, nil if the handler returned an error
+	Stack []byte
+}
+
+// Error returns the error message
+func (e *HandlerError) Error() string {
+	return fmt.Sprintf("%s handler failed: %v", e.ContextType, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *HandlerError) Unwrap() error {
+	return e.Err
+}
+
+// IsPanic returns true if the handler panicked
+func (e *HandlerError) IsPanic() bool {
+	return e.Stack != nil
+}
+
+// ErrorHandler represents the function invoked when a handler fails
+type ErrorHandler func(*HandlerError)
+
+// newErrorReporter creates a new error reporter structure
+func newErrorReporter(logger StructuredLogger, replyErrors bool) *errorReporter {
+	return &errorReporter{logger: logger, replyErrors: replyErrors}
+}
+
+// errorReporter logs handler failures, replies to users if enabled and
+// invokes the OnError hook
+type errorReporter struct {
+	logger      StructuredLogger
+	replyErrors bool
+	onError     ErrorHandler
+}
+
+// recoverCommand reports the panic of a command handler, if any. It must be deferred.
+func (r *errorReporter) recoverCommand(ctx *CommandContext) {
+	if recovered := recover(); recovered != nil {
+		r.reportCommand(ctx, newCommandError(ctx, nil).withPanic(recovered))
+	}
+}
+
+// reportCommand reports the failure of a command handler
+func (r *errorReporter) reportCommand(ctx *CommandContext, err *HandlerError) {
+	if r.replyErrors {
+		if _, replyErr := ctx.Response().ReplyError(err.userFacingError(), WithEphemeral()); replyErr != nil {
+			r.logger.Error("failed to reply error", logKeyError, replyErr)
+		}
+	}
+
+	r.report(err)
+}
+
+// recoverInteraction reports the panic of an interaction handler, if any. It must be deferred.
+func (r *errorReporter) recoverInteraction(ctx *InteractionContext) {
+	if recovered := recover(); recovered != nil {
+		r.reportInteraction(ctx, newInteractionError(ctx, nil).withPanic(recovered))
+	}
+}
+
+// reportInteraction reports the failure of an interaction handler
+func (r *errorReporter) reportInteraction(ctx *InteractionContext, err *HandlerError) {
+	if r.replyErrors && len(interactionChannelID(ctx.callback)) > 0 {
+		if _, replyErr := ctx.Response().ReplyError(err.userFacingError(), WithEphemeral()); replyErr != nil {
+			r.logger.Error("failed to reply error", logKeyError, replyErr)
+		}
+	}
+
+	r.report(err)
+}
+
+// recoverJob reports the panic of a job handler, if any, failing the attempt.
+// It must be deferred.
+func (r *errorReporter) recoverJob(ctx *JobContext) {
+	if recovered := recover(); recovered != nil {
+		err := newJobError(ctx, nil).withPanic(recovered)
+		ctx.err = err
+		r.report(err)
+	}
+}
+
+// recoverReaction reports the panic of a reaction handler, if any. It must be deferred.
+func (r *errorReporter) recoverReaction(ctx *ReactionContext) {
+	if recovered := recover(); recovered != nil {
+		r.reportReaction(ctx, newReactionError(ctx, nil).withPanic(recovered))
+	}
+}
+
+// reportReaction reports the failure of a reaction handler
+func (r *errorReporter) reportReaction(ctx *ReactionContext, err *HandlerError) {
+	if r.replyErrors {
+		if _, replyErr := ctx.Response().ReplyError(err.userFacingError(), WithEphemeral()); replyErr != nil {
+			r.logger.Error("failed to reply error", logKeyError, replyErr)
+		}
+	}
+
+	r.report(err)
+}
+
+// recoverEvent reports the panic of an event handler, if any. It must be deferred.
+func (r *errorReporter) recoverEvent(ctx *EventContext) {
+	if recovered := recover(); recovered != nil {
+		r.report(newEventError(ctx, nil).withPanic(recovered))
+	}
+}
+
+// recoverConversation reports the panic of a conversation step handler, if any. It must be deferred.
+func (r *errorReporter) recoverConversation(ctx *ConversationContext) {
+	if recovered := recover(); recovered != nil {
+		r.reportConversation(ctx, newConversationError(ctx, nil).withPanic(recovered))
+	}
+}
+
+// reportConversation reports the failure of a conversation step handler
+func (r *errorReporter) reportConversation(ctx *ConversationContext, err *HandlerError) {
+	if r.replyErrors {
+		if _, replyErr := ctx.Response().ReplyError(err.userFacingError(), WithEphemeral()); replyErr != nil {
+			r.logger.Error("failed to reply error", logKeyError, replyErr)
+		}
+	}
+
+	r.report(err)
+}
+
+// recoverAppHome reports the panic of the App Home handler, if any. It must be deferred.
+// The panic is kept as the context error so that no view gets published.
+func (r *errorReporter) recoverAppHome(ctx *AppHomeContext) {
+	if recovered := recover(); recovered != nil {
+		err := newAppHomeError(ctx, nil).withPanic(recovered)
+		ctx.err = err
+		r.report(err)
+	}
+}
+
+// recoverOptions reports the panic of the options handler, if any. It must be deferred.
+// The panic is kept as the context error so that no options are suggested.
+func (r *errorReporter) recoverOptions(ctx *OptionsContext) {
+	if recovered := recover(); recovered != nil {
+		err := newOptionsError(ctx, nil).withPanic(recovered)
+		ctx.err = err
+		r.report(err)
+	}
+}
+
+// recoverFunction reports the panic of the function handler, if any, and fails
+// the step unless it already completed. It must be deferred.
+func (r *errorReporter) recoverFunction(ctx *FunctionContext) {
+	if recovered := recover(); recovered != nil {
+		r.reportFunction(ctx, newFunctionError(ctx, nil).withPanic(recovered))
+	}
+}
+
+// reportFunction reports the error and fails the step, unless it already completed
+func (r *errorReporter) reportFunction(ctx *FunctionContext, err *HandlerError) {
+	r.report(err)
+
+	if !ctx.completed {
+		ctx.Fail(err.userFacingError().Error())
+	}
+}
+
+func (r *errorReporter) report(err *HandlerError) {
+	if err.IsPanic() {
+		r.logger.Error(err.Error(), "stack", string(err.Stack))
+	} else {
+		r.logger.Error(err.Error())
+	}
+
+	if r.onError != nil {
+		r.onError(err)
+	}
+}
+
+func newCommandError(ctx *CommandContext, err error) *HandlerError {
+	handlerError := &HandlerError{ContextType: ContextTypeCommand, Context: ctx, Err: err}
+	if ctx.definition != nil {
+		handlerError.Definition = ctx.definition
+	}
+	return handlerError
+}
+
+func newInteractionError(ctx *InteractionContext, err error) *HandlerError {
+	handlerError := &HandlerError{ContextType: ContextTypeInteraction, Context: ctx, Err: err}
+	if ctx.definition != nil {
+		handlerError.Definition = ctx.definition
+	}
+	return handlerError
+}
+
+func newJobError(ctx *JobContext, err error) *HandlerError {
+	return &HandlerError{ContextType: ContextTypeJob, Context: ctx, Definition: ctx.definition, Err: err}
+}
+
+func newEventError(ctx *EventContext, err error) *HandlerError {
+	return &HandlerError{ContextType: ContextTypeEvent, Context: ctx, Definition: ctx.definition, Err: err}
+}
+
+func newReactionError(ctx *ReactionContext, err error) *HandlerError {
+	return &HandlerError{ContextType: ContextTypeReaction, Context: ctx, Definition: ctx.definition, Err: err}
+}
+
+func newAppHomeError(ctx *AppHomeContext, err error) *HandlerError {
+	return &HandlerError{ContextType: ContextTypeAppHome, Context: ctx, Definition: ctx.definition, Err: err}
+}
+
+func newConversationError(ctx *ConversationContext, err error) *HandlerError {
+	return &HandlerError{ContextType: ContextTypeConversation, Context: ctx, Definition: ctx.definition, Err: err}
+}
+
+func newOptionsError(ctx *OptionsContext, err error) *HandlerError {
+	return &HandlerError{ContextType: ContextTypeOptions, Context: ctx, Definition: ctx.definition, Err: err}
+}
+
+func newFunctionError(ctx *FunctionContext, err error) *HandlerError {
+	return &HandlerError{ContextType: ContextTypeFunction, Context: ctx, Definition: ctx.definition, Err: err}
+}
+
+// withPanic records the recovered value along with the current stack
+func (e *HandlerError) withPanic(recovered any) *HandlerError {
+	err, ok := recovered.(error)
+	if !ok {
+		err = fmt.Errorf("panic: %v", recovered)
+	}
+
+	e.Err = err
+	e.Recovered = recovered
+	e.Stack = debug.Stack()
+	return e
+}
+
+// userFacingError hides the details of panics from users
+func (e *HandlerError) userFacingError() error {
+	if e.IsPanic() {
+		return errors.New(defaultPanicMessage)
+	}
+	return e.Err
+}
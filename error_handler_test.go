@@ -0,0 +1,237 @@
+package slacker_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shomali11/slacker/v2"
+	"github.com/shomali11/slacker/v2/slackertest"
+	"github.com/slack-go/slack"
+	"github.com/slack-go/slack/slackevents"
+)
+
+// failingHandlerTests registers a handler of each kind failing with fail and
+// sends what triggers it. The definition is the one expected to be reported.
+var failingHandlerTests = []struct {
+	name        string
+	contextType slacker.ContextType
+	register    func(bot *slacker.Slacker, fail func() error) any
+	trigger     func(harness *slackertest.Harness)
+}{
+	{
+		name:        "command",
+		contextType: slacker.ContextTypeCommand,
+		register: func(bot *slacker.Slacker, fail func() error) any {
+			definition := &slacker.CommandDefinition{
+				Command: "ping",
+				Handler: slacker.CommandHandlerWithError(func(*slacker.CommandContext) error {
+					return fail()
+				}),
+			}
+			bot.AddCommand(definition)
+			return definition
+		},
+		trigger: func(harness *slackertest.Harness) {
+			harness.SendMessage("C123", "U123", "ping")
+		},
+	},
+	{
+		name:        "interaction",
+		contextType: slacker.ContextTypeInteraction,
+		register: func(bot *slacker.Slacker, fail func() error) any {
+			definition := &slacker.InteractionDefinition{
+				Type:     slack.InteractionTypeBlockActions,
+				ActionID: "approve",
+				Handler: slacker.InteractionHandlerWithError(func(*slacker.InteractionContext) error {
+					return fail()
+				}),
+			}
+			bot.AddInteraction(definition)
+			return definition
+		},
+		trigger: func(harness *slackertest.Harness) {
+			harness.SendInteraction(newBlockActionsCallback(&slack.BlockAction{ActionID: "approve"}))
+		},
+	},
+	{
+		name:        "reaction",
+		contextType: slacker.ContextTypeReaction,
+		register: func(bot *slacker.Slacker, fail func() error) any {
+			definition := &slacker.ReactionDefinition{
+				Emoji: "eyes",
+				Handler: slacker.ReactionHandlerWithError(func(*slacker.ReactionContext) error {
+					return fail()
+				}),
+			}
+			bot.AddReaction(definition)
+			return definition
+		},
+		trigger: func(harness *slackertest.Harness) {
+			timeStamp := harness.SendMessage("C123", "U123", "hello")
+			harness.SendReaction("C123", "U123", timeStamp, "eyes")
+		},
+	},
+	{
+		name:        "event",
+		contextType: slacker.ContextTypeEvent,
+		register: func(bot *slacker.Slacker, fail func() error) any {
+			definition := &slacker.EventDefinition{
+				Type: slackevents.MemberJoinedChannel,
+				Handler: slacker.EventHandlerWithError(func(*slacker.EventContext) error {
+					return fail()
+				}),
+			}
+			bot.AddEvent(definition)
+			return definition
+		},
+		trigger: func(harness *slackertest.Harness) {
+			harness.SendEvent(slackevents.EventsAPIEvent{
+				Type: slackevents.CallbackEvent,
+				InnerEvent: slackevents.EventsAPIInnerEvent{
+					Type: string(slackevents.MemberJoinedChannel),
+					Data: &slackevents.MemberJoinedChannelEvent{
+						Type:    string(slackevents.MemberJoinedChannel),
+						User:    "U123",
+						Channel: "C123",
+					},
+				},
+			})
+		},
+	},
+	{
+		name:        "app home",
+		contextType: slacker.ContextTypeAppHome,
+		register: func(bot *slacker.Slacker, fail func() error) any {
+			definition := &slacker.AppHomeDefinition{
+				Handler: slacker.AppHomeHandlerWithError(func(*slacker.AppHomeContext) error {
+					return fail()
+				}),
+			}
+			bot.AppHome(definition)
+			return definition
+		},
+		trigger: func(harness *slackertest.Harness) {
+			harness.OpenAppHome("U123")
+		},
+	},
+	{
+		name:        "options",
+		contextType: slacker.ContextTypeOptions,
+		register: func(bot *slacker.Slacker, fail func() error) any {
+			definition := &slacker.OptionsDefinition{
+				ActionID: "assignee",
+				Handler: slacker.OptionsHandlerWithError(func(*slacker.OptionsContext) error {
+					return fail()
+				}),
+			}
+			bot.AddOptions(definition)
+			return definition
+		},
+		trigger: func(harness *slackertest.Harness) {
+			callback := &slack.InteractionCallback{Type: slack.InteractionTypeBlockSuggestion, ActionID: "assignee"}
+			callback.User.ID = "U123"
+			harness.SubmitInteraction(callback)
+		},
+	},
+	{
+		name:        "function",
+		contextType: slacker.ContextTypeFunction,
+		register: func(bot *slacker.Slacker, fail func() error) any {
+			definition := &slacker.FunctionDefinition{
+				CallbackID: "step",
+				Handler: slacker.FunctionHandlerWithError(func(*slacker.FunctionContext) error {
+					return fail()
+				}),
+			}
+			bot.AddFunction(definition)
+			return definition
+		},
+		trigger: func(harness *slackertest.Harness) {
+			harness.ExecuteFunction("step", nil)
+		},
+	},
+	{
+		name:        "job",
+		contextType: slacker.ContextTypeJob,
+		register: func(bot *slacker.Slacker, fail func() error) any {
+			definition := &slacker.JobDefinition{
+				Name:           "report",
+				CronExpression: "0 0 1 1 *",
+				Handler: slacker.JobHandlerWithError(func(*slacker.JobContext) error {
+					return fail()
+				}),
+			}
+			bot.AddJob(definition)
+			return definition
+		},
+		trigger: func(harness *slackertest.Harness) {
+			harness.RunJob("report")
+		},
+	},
+}
+
+func TestHandlerPanicsAreRecovered(t *testing.T) {
+	for _, test := range failingHandlerTests {
+		t.Run(test.name, func(t *testing.T) {
+			harness := slackertest.NewHarness()
+			defer harness.Close()
+
+			var reported []*slacker.HandlerError
+			harness.Bot().OnError(func(err *slacker.HandlerError) {
+				reported = append(reported, err)
+			})
+			definition := test.register(harness.Bot(), func() error {
+				panic("boom")
+			})
+
+			test.trigger(harness)
+
+			if len(reported) != 1 {
+				t.Fatalf("expected the panic to be reported once, got %d reports", len(reported))
+			}
+
+			err := reported[0]
+			if !err.IsPanic() || len(err.Stack) == 0 || err.Recovered != "boom" {
+				t.Errorf("expected a panic with its stack, got %+v", err)
+			}
+			if err.ContextType != test.contextType || err.Definition != definition {
+				t.Errorf("expected the %s definition to be reported, got %s %+v", test.contextType, err.ContextType, err.Definition)
+			}
+		})
+	}
+}
+
+func TestHandlerErrorsAreReported(t *testing.T) {
+	for _, test := range failingHandlerTests {
+		t.Run(test.name, func(t *testing.T) {
+			harness := slackertest.NewHarness()
+			defer harness.Close()
+
+			var reported []*slacker.HandlerError
+			harness.Bot().OnError(func(err *slacker.HandlerError) {
+				reported = append(reported, err)
+			})
+			failure := errors.New("failure")
+			definition := test.register(harness.Bot(), func() error {
+				return failure
+			})
+
+			test.trigger(harness)
+
+			if len(reported) != 1 {
+				t.Fatalf("expected the error to be reported once, got %d reports", len(reported))
+			}
+
+			err := reported[0]
+			if err.IsPanic() || !errors.Is(err, failure) {
+				t.Errorf("expected the returned error, got %+v", err)
+			}
+			if err.ContextType != test.contextType || err.Definition != definition {
+				t.Errorf("expected the %s definition to be reported, got %s %+v", test.contextType, err.ContextType, err.Definition)
+			}
+			if err.Context == nil {
+				t.Error("expected the handler context to be reported")
+			}
+		})
+	}
+}
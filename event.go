@@ -0,0 +1,74 @@
+package slacker
+
+import (
+	"github.com/slack-go/slack/slackevents"
+)
+
+// EventDefinition structure contains definition of the bot event handler
+type EventDefinition struct {
+	// Type is the Events API event type, such as `reaction_added` or `team_join`.
+	// `message` and `app_mention` events are routed to commands instead.
+	Type        slackevents.EventsAPIType
+	Middlewares []EventMiddlewareHandler
+	Handler     EventHandler
+}
+
+// newEvent creates a new bot event object
+func newEvent(definition *EventDefinition) *Event {
+	return &Event{
+		definition: definition,
+	}
+}
+
+// Event structure contains the bot's event handler
+type Event struct {
+	definition *EventDefinition
+}
+
+// Definition returns the event definition
+func (c *Event) Definition() *EventDefinition {
+	return c.definition
+}
+
+// TypedEventHandler adapts a handler receiving the event data as T, such as
+// *slackevents.ReactionAddedEvent, into an EventHandler. Events whose data is
+// not a T are ignored.
+func TypedEventHandler[T any](handler func(*EventContext, T)) EventHandler {
+	return func(ctx *EventContext) {
+		data, ok := ctx.Data().(T)
+		if !ok {
+			ctx.StructuredLogger().Debug("ignored event with unexpected data")
+			return
+		}
+		handler(ctx, data)
+	}
+}
+
+// eventChannelUser returns the channel and user an event relates to, if any
+func eventChannelUser(data any) (string, string) {
+	switch ev := data.(type) {
+	case *slackevents.MessageEvent:
+		return ev.Channel, ev.User
+	case *slackevents.AppMentionEvent:
+		return ev.Channel, ev.User
+	case *slackevents.ReactionAddedEvent:
+		return ev.Item.Channel, ev.User
+	case *slackevents.ReactionRemovedEvent:
+		return ev.Item.Channel, ev.User
+	case *slackevents.MemberJoinedChannelEvent:
+		return ev.Channel, ev.User
+	case *slackevents.MemberLeftChannelEvent:
+		return ev.Channel, ev.User
+	case *slackevents.AppHomeOpenedEvent:
+		return ev.Channel, ev.User
+	case *slackevents.ChannelCreatedEvent:
+		return ev.Channel.ID, ev.Channel.Creator
+	case *slackevents.FileSharedEvent:
+		return ev.ChannelID, ev.UserID
+	case *slackevents.TeamJoinEvent:
+		if ev.User != nil {
+			return "", ev.User.ID
+		}
+	}
+	return "", ""
+}
@@ -0,0 +1,91 @@
+package slacker_test
+
+import (
+	"testing"
+
+	"github.com/shomali11/slacker/v2"
+	"github.com/shomali11/slacker/v2/slackertest"
+	"github.com/slack-go/slack/slackevents"
+)
+
+// newCallbackEvent wraps the data into an Events API callback event of the type
+func newCallbackEvent(eventType slackevents.EventsAPIType, data any) slackevents.EventsAPIEvent {
+	return slackevents.EventsAPIEvent{
+		Type: slackevents.CallbackEvent,
+		InnerEvent: slackevents.EventsAPIInnerEvent{
+			Type: string(eventType),
+			Data: data,
+		},
+	}
+}
+
+func TestTypedEventHandler(t *testing.T) {
+	harness := slackertest.NewHarness()
+	defer harness.Close()
+
+	harness.Bot().AddEvent(&slacker.EventDefinition{
+		Type: slackevents.MemberJoinedChannel,
+		Handler: slacker.TypedEventHandler(func(ctx *slacker.EventContext, event *slackevents.MemberJoinedChannelEvent) {
+			ctx.Response().Post(event.Channel, "Welcome <@"+event.User+">!")
+		}),
+	})
+
+	harness.SendEvent(newCallbackEvent(slackevents.MemberJoinedChannel, &slackevents.MemberJoinedChannelEvent{
+		Type:    string(slackevents.MemberJoinedChannel),
+		User:    "U456",
+		Channel: "C123",
+	}))
+
+	messages := harness.Messages()
+	if len(messages) != 1 || messages[0].Channel != "C123" || messages[0].Text != "Welcome <@U456>!" {
+		t.Errorf("expected the handler to receive the typed event, got %+v", messages)
+	}
+}
+
+func TestTypedEventHandlerAlongsideReactions(t *testing.T) {
+	harness := slackertest.NewHarness()
+	defer harness.Close()
+
+	var reacted bool
+	harness.Bot().AddReaction(&slacker.ReactionDefinition{
+		Emoji: "eyes",
+		Handler: func(*slacker.ReactionContext) {
+			reacted = true
+		},
+	})
+
+	var emoji string
+	harness.Bot().AddEvent(&slacker.EventDefinition{
+		Type: slackevents.ReactionAdded,
+		Handler: slacker.TypedEventHandler(func(ctx *slacker.EventContext, event *slackevents.ReactionAddedEvent) {
+			emoji = event.Reaction
+		}),
+	})
+
+	timeStamp := harness.SendMessage("C123", "U123", "hello")
+	harness.SendReaction("C123", "U123", timeStamp, "eyes")
+
+	if !reacted || emoji != "eyes" {
+		t.Errorf("expected both the reaction and the event handlers to run, got %t %q", reacted, emoji)
+	}
+}
+
+func TestTypedEventHandlerTypeMismatch(t *testing.T) {
+	harness := slackertest.NewHarness()
+	defer harness.Close()
+
+	var handled bool
+	harness.Bot().AddEvent(&slacker.EventDefinition{
+		Type: slackevents.ReactionAdded,
+		Handler: slacker.TypedEventHandler(func(*slacker.EventContext, *slackevents.MemberJoinedChannelEvent) {
+			handled = true
+		}),
+	})
+
+	timeStamp := harness.SendMessage("C123", "U123", "hello")
+	harness.SendReaction("C123", "U123", timeStamp, "eyes")
+
+	if handled {
+		t.Error("expected the event with unexpected data to be ignored")
+	}
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+
+	"github.com/shomali11/slacker/v2"
+	"github.com/slack-go/slack"
+)
+
+// Rendering a per-user App Home tab with a counter, incremented by a button on the home tab
+// and reset by the `reset` command. Remember to enable the Home Tab and to subscribe to the
+// `app_home_opened` bot event.
+
+func main() {
+	var mutex sync.Mutex
+	counters := make(map[string]int)
+
+	bot := slacker.NewClient(os.Getenv("SLACK_BOT_TOKEN"), os.Getenv("SLACK_APP_TOKEN"))
+
+	bot.AppHome(&slacker.AppHomeDefinition{
+		RefreshOnAction: true,
+		Handler: func(ctx *slacker.AppHomeContext) {
+			mutex.Lock()
+			count := counters[ctx.UserID()]
+			mutex.Unlock()
+
+			text := slack.NewTextBlockObject(slack.MarkdownType, fmt.Sprintf("You clicked *%d* times", count), false, false)
+			button := slack.NewButtonBlockElement("increment", "", slack.NewTextBlockObject(slack.PlainTextType, "Click me", false, false))
+
+			ctx.Render(slack.HomeTabViewRequest{
+				Blocks: slack.Blocks{BlockSet: []slack.Block{
+					slack.NewSectionBlock(text, nil, nil),
+					slack.NewActionBlock("counter", button),
+				}},
+			})
+		},
+	})
+
+	bot.AddInteraction(&slacker.InteractionDefinition{
+		InteractionID: "counter",
+		Type:          slack.InteractionTypeBlockActions,
+		Handler: func(ctx *slacker.InteractionContext) {
+			mutex.Lock()
+			counters[ctx.Callback().User.ID]++
+			mutex.Unlock()
+		},
+	})
+
+	bot.AddCommand(&slacker.CommandDefinition{
+		Command:     "reset",
+		Description: "Resets your App Home counter",
+		Handler: func(ctx *slacker.CommandContext) {
+			userID := ctx.Event().UserID
+
+			mutex.Lock()
+			delete(counters, userID)
+			mutex.Unlock()
+
+			if err := bot.PublishAppHome(ctx.Context(), userID); err != nil {
+				ctx.Response().ReplyError(err)
+				return
+			}
+			ctx.Response().Reply("Your counter was reset")
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err := bot.Listen(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+
+	"github.com/shomali11/slacker/v2"
+	"github.com/slack-go/slack"
+)
+
+// Restricting commands, command groups and interactions to users holding specific roles.
+// Unauthorized users receive an ephemeral denial and do not see restricted commands in `help`.
+
+func main() {
+	// Providers relying on the Slack API need a client of their own
+	slackClient := slack.New(os.Getenv("SLACK_BOT_TOKEN"))
+
+	roleProvider := slacker.NewCompositeRoleProvider(
+		slacker.NewStaticRoleProvider(map[string][]string{
+			"deployer": {"U0123456789"},
+		}),
+		slacker.NewUserGroupRoleProvider(slackClient, map[string]string{
+			"oncall": "S0123456789",
+		}),
+		slacker.NewAdminRoleProvider(slackClient),
+	)
+
+	bot := slacker.NewClient(
+		os.Getenv("SLACK_BOT_TOKEN"),
+		os.Getenv("SLACK_APP_TOKEN"),
+		slacker.WithRoleProvider(roleProvider),
+	)
+
+	bot.AddCommand(&slacker.CommandDefinition{
+		Command:     "deploy {environment}",
+		Description: "Deploy to an environment",
+		Roles:       []string{"deployer", slacker.RoleAdmin},
+		Handler: func(ctx *slacker.CommandContext) {
+			ctx.Response().Reply("Deploying to " + ctx.Request().Param("environment"))
+		},
+	})
+
+	admin := bot.AddCommandGroup("admin")
+	admin.AddRoles(slacker.RoleAdmin, slacker.RoleOwner)
+	admin.AddCommand(&slacker.CommandDefinition{
+		Command:     "restart",
+		Description: "Restart the service",
+		Handler: func(ctx *slacker.CommandContext) {
+			ctx.Response().Reply("Restarting...")
+		},
+	})
+
+	bot.AddInteraction(&slacker.InteractionDefinition{
+		InteractionID: "page",
+		Type:          slack.InteractionTypeBlockActions,
+		Roles:         []string{"oncall"},
+		Handler: func(ctx *slacker.InteractionContext) {
+			ctx.Response().Reply("Paging the on-call engineer")
+		},
+	})
+
+	bot.UnauthorizedCommandHandler(func(ctx *slacker.CommandContext) {
+		ctx.Response().Reply("Sorry, you are not allowed to do that!", slacker.WithEphemeral())
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err := bot.Listen(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/shomali11/slacker/v2"
+)
+
+// Binding command parameters to a struct. Mentions such as @john or #general are converted into IDs.
+
+type RemindArgs struct {
+	UserID    string        `slacker:"user"`
+	ChannelID string        `slacker:"channel"`
+	Delay     time.Duration `slacker:"delay"`
+	Count     *int          `slacker:"count"`
+}
+
+func main() {
+	bot := slacker.NewClient(os.Getenv("SLACK_BOT_TOKEN"), os.Getenv("SLACK_APP_TOKEN"))
+
+	bot.AddCommand(&slacker.CommandDefinition{
+		Command:     "remind {user} {channel} {delay} {count}",
+		Description: "Remind a user in a channel after a delay",
+		Examples:    []string{"remind @john #general 10m 3"},
+		Parameters: []*slacker.ParameterDefinition{
+			{Name: "user", Type: slacker.ParameterTypeUser},
+			{Name: "channel", Type: slacker.ParameterTypeChannel},
+			{Name: "delay", Type: slacker.ParameterTypeDuration},
+			{Name: "count", Type: slacker.ParameterTypeInteger, Optional: true},
+		},
+		Handler: slacker.TypedCommandHandler(func(ctx *slacker.CommandContext, args *RemindArgs) {
+			count := 1
+			if args.Count != nil {
+				count = *args.Count
+			}
+
+			message := fmt.Sprintf("Reminding <@%s> in <#%s> %d time(s) in %s", args.UserID, args.ChannelID, count, args.Delay)
+			ctx.Response().Reply(message)
+		}),
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err := bot.Listen(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -77,7 +77,7 @@ func LoggingCommandMiddleware() slacker.CommandMiddlewareHandler {
 				ctx.Event().UserID,
 				ctx.Definition().Command,
 				ctx.Request().Properties(),
-				ctx.Event().Channel.ID,
+				ctx.Event().GetChannel().ID,
 			)
 			next(ctx)
 		}
@@ -39,7 +39,7 @@ func main() {
 func authorizationMiddleware() slacker.CommandMiddlewareHandler {
 	return func(next slacker.CommandHandler) slacker.CommandHandler {
 		return func(ctx *slacker.CommandContext) {
-			if contains(authorizedUserNames, ctx.Event().UserProfile.DisplayName) {
+			if contains(authorizedUserNames, ctx.Event().GetUserProfile().DisplayName) {
 				next(ctx)
 			}
 		}
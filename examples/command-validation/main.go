@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/shomali11/slacker/v2"
+)
+
+// Declaring typed parameters. Invalid input is rejected with a usage message before the handler runs,
+// and `help` displays the declared types.
+
+func main() {
+	bot := slacker.NewClient(os.Getenv("SLACK_BOT_TOKEN"), os.Getenv("SLACK_APP_TOKEN"))
+
+	maxCount := float64(10)
+
+	bot.AddCommand(&slacker.CommandDefinition{
+		Command:     "remind {user} {delay} {count}",
+		Description: "Remind a user after a delay",
+		Examples:    []string{"remind @john 10m 3"},
+		Parameters: []*slacker.ParameterDefinition{
+			{Name: "user", Type: slacker.ParameterTypeUser, Description: "User to remind"},
+			{Name: "delay", Type: slacker.ParameterTypeDuration, Description: "How long to wait, for instance `1h30m`"},
+			{Name: "count", Type: slacker.ParameterTypeInteger, Optional: true, Max: &maxCount, Description: "Number of reminders"},
+		},
+		Handler: func(ctx *slacker.CommandContext) {
+			delay, _ := time.ParseDuration(ctx.Request().Param("delay"))
+			count := ctx.Request().IntegerParam("count", 1)
+			ctx.Response().Reply(fmt.Sprintf("Reminding %s %d time(s) in %s", ctx.Request().Param("user"), count, delay))
+		},
+	})
+
+	bot.AddCommand(&slacker.CommandDefinition{
+		Command:     "deploy {environment} {date}",
+		Description: "Schedule a deployment",
+		Examples:    []string{"deploy staging 2024-01-31"},
+		Parameters: []*slacker.ParameterDefinition{
+			{Name: "environment", Type: slacker.ParameterTypeEnum, Values: []string{"staging", "production"}},
+			{Name: "date", Type: slacker.ParameterTypeDate},
+		},
+		Handler: func(ctx *slacker.CommandContext) {
+			ctx.Response().Reply("Deploying to " + ctx.Request().Param("environment"))
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err := bot.Listen(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
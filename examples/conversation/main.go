@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/shomali11/slacker/v2"
+)
+
+// Ordering a pizza through a conversation in the thread of the `order` command.
+// Reply `cancel` in the thread to stop ordering.
+
+func main() {
+	bot := slacker.NewClient(os.Getenv("SLACK_BOT_TOKEN"), os.Getenv("SLACK_APP_TOKEN"))
+
+	bot.AddConversation(&slacker.ConversationDefinition{
+		Name:    "order",
+		Timeout: 5 * time.Minute,
+		Steps: []*slacker.ConversationStep{
+			{
+				Name:   "size",
+				Prompt: "Which size? small, medium or large",
+				Handler: func(ctx *slacker.ConversationContext) {
+					size := ctx.Event().Text
+					if size != "small" && size != "medium" && size != "large" {
+						ctx.Response().Reply("Please pick small, medium or large")
+						return
+					}
+
+					ctx.SetValue("size", size)
+					ctx.Next("quantity")
+				},
+			},
+			{
+				Name:   "quantity",
+				Prompt: "How many?",
+				Handler: func(ctx *slacker.ConversationContext) {
+					quantity, err := strconv.Atoi(ctx.Event().Text)
+					if err != nil || quantity <= 0 {
+						ctx.Response().Reply("Please reply with a number")
+						return
+					}
+
+					ctx.Response().Reply(fmt.Sprintf("Ordered %d %s pizza(s) for <@%s> :pizza:", quantity, ctx.Value("size"), ctx.Value("user")))
+					ctx.End()
+				},
+			},
+		},
+	})
+
+	bot.AddCommand(&slacker.CommandDefinition{
+		Command:     "order",
+		Description: "Orders a pizza",
+		Handler: func(ctx *slacker.CommandContext) {
+			values := map[string]string{"user": ctx.Event().UserID}
+			if err := ctx.StartConversation("order", values); err != nil {
+				ctx.Response().ReplyError(err)
+			}
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err := bot.Listen(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+
+	"github.com/shomali11/slacker/v2"
+)
+
+// Recovering from panics and reporting errors returned by handlers. Users are replied to with an
+// ephemeral error and every failure is passed to the OnError hook.
+
+func main() {
+	bot := slacker.NewClient(
+		os.Getenv("SLACK_BOT_TOKEN"),
+		os.Getenv("SLACK_APP_TOKEN"),
+		slacker.WithErrorReplies(true),
+	)
+
+	bot.OnError(func(err *slacker.HandlerError) {
+		if err.IsPanic() {
+			log.Printf("%s handler panicked with %v", err.ContextType, err.Recovered)
+			return
+		}
+
+		if definition, ok := err.Definition.(*slacker.CommandDefinition); ok {
+			log.Printf("command %q failed: %v", definition.Command, err.Err)
+		}
+	})
+
+	bot.AddCommand(&slacker.CommandDefinition{
+		Command: "panic",
+		Handler: func(ctx *slacker.CommandContext) {
+			panic("oops")
+		},
+	})
+
+	bot.AddCommand(&slacker.CommandDefinition{
+		Command: "fail",
+		Handler: slacker.CommandHandlerWithError(func(ctx *slacker.CommandContext) error {
+			return errors.New("could not do it")
+		}),
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err := bot.Listen(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/shomali11/slacker/v2"
+	"github.com/slack-go/slack/slackevents"
+)
+
+// Handling Events API events other than messages with typed handlers.
+// Remember to subscribe to the `member_joined_channel` and `reaction_added` bot events.
+
+func main() {
+	bot := slacker.NewClient(os.Getenv("SLACK_BOT_TOKEN"), os.Getenv("SLACK_APP_TOKEN"))
+
+	bot.AddEventMiddleware(func(next slacker.EventHandler) slacker.EventHandler {
+		return func(ctx *slacker.EventContext) {
+			ctx.Logger().Infof("received %s event\n", ctx.Event().InnerEvent.Type)
+			next(ctx)
+		}
+	})
+
+	bot.AddEvent(&slacker.EventDefinition{
+		Type: slackevents.MemberJoinedChannel,
+		Handler: slacker.TypedEventHandler(func(ctx *slacker.EventContext, event *slackevents.MemberJoinedChannelEvent) {
+			ctx.Response().Post(event.Channel, fmt.Sprintf("Welcome <@%s>!", event.User))
+		}),
+	})
+
+	bot.AddEvent(&slacker.EventDefinition{
+		Type: slackevents.ReactionAdded,
+		Handler: slacker.TypedEventHandler(func(ctx *slacker.EventContext, event *slackevents.ReactionAddedEvent) {
+			ctx.Logger().Infof("<@%s> reacted with :%s:\n", event.User, event.Reaction)
+		}),
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err := bot.Listen(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/shomali11/slacker/v2"
+	"github.com/slack-go/slack"
+)
+
+// Suggesting the options of an external select as the user types, grouped by
+// department. The app's interactivity settings need an options load URL, any URL
+// works with Socket Mode.
+
+var teams = map[string][]string{
+	"Engineering": {"Backend", "Frontend", "Infrastructure", "Mobile"},
+	"Business":    {"Finance", "Marketing", "Sales"},
+}
+
+func main() {
+	bot := slacker.NewClient(os.Getenv("SLACK_BOT_TOKEN"), os.Getenv("SLACK_APP_TOKEN"))
+
+	bot.AddCommand(&slacker.CommandDefinition{
+		Command:     "join",
+		Description: "Joins a team",
+		Handler: func(ctx *slacker.CommandContext) {
+			teamSelect := slack.NewOptionsSelectBlockElement(
+				slack.OptTypeExternal,
+				slack.NewTextBlockObject(slack.PlainTextType, "Search teams", false, false),
+				"team",
+			)
+			minQueryLength := 0
+			teamSelect.MinQueryLength = &minQueryLength
+
+			ctx.Response().ReplyBlocks([]slack.Block{
+				slack.NewSectionBlock(slack.NewTextBlockObject(slack.MarkdownType, "Which team are you joining?", false, false), nil, slack.NewAccessory(teamSelect)),
+			})
+		},
+	})
+
+	bot.AddOptions(&slacker.OptionsDefinition{
+		ActionID: "team",
+		Handler: func(ctx *slacker.OptionsContext) {
+			query := strings.ToLower(ctx.Query().Value)
+
+			groups := []*slack.OptionGroupBlockObject{}
+			for department, names := range teams {
+				options := []*slack.OptionBlockObject{}
+				for _, name := range names {
+					if strings.Contains(strings.ToLower(name), query) {
+						options = append(options, slack.NewOptionBlockObject(name, slack.NewTextBlockObject(slack.PlainTextType, name, false, false), nil))
+					}
+				}
+
+				if len(options) > 0 {
+					groups = append(groups, slack.NewOptionGroupBlockElement(slack.NewTextBlockObject(slack.PlainTextType, department, false, false), options...))
+				}
+			}
+			ctx.RespondGroups(groups...)
+		},
+	})
+
+	bot.AddInteraction(&slacker.InteractionDefinition{
+		ActionID: "team",
+		Type:     slack.InteractionTypeBlockActions,
+		Handler: func(ctx *slacker.InteractionContext) {
+			ctx.Response().Reply("Welcome to " + ctx.Action().SelectedOption.Value + "!")
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err := bot.Listen(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
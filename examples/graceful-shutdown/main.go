@@ -0,0 +1,43 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/shomali11/slacker/v2"
+)
+
+// Draining in-flight handlers before exiting. Once the context is canceled, new events are ignored
+// and running handlers get up to 30 seconds to complete before their context is canceled.
+
+func main() {
+	bot := slacker.NewClient(
+		os.Getenv("SLACK_BOT_TOKEN"),
+		os.Getenv("SLACK_APP_TOKEN"),
+		slacker.WithShutdownTimeout(30*time.Second),
+	)
+
+	bot.AddCommand(&slacker.CommandDefinition{
+		Command: "slow",
+		Handler: func(ctx *slacker.CommandContext) {
+			select {
+			case <-time.After(10 * time.Second):
+				ctx.Response().Reply("Done!")
+			case <-ctx.Context().Done():
+				ctx.Logger().Info("gave up on slow command")
+			}
+		},
+	})
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	err := bot.Listen(ctx)
+	if err != nil && err != context.Canceled {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+
+	"github.com/shomali11/slacker/v2"
+)
+
+// Receiving events over HTTP using the Events API request URLs instead of Socket Mode.
+// Point your app's request URLs to `/slack/events`, `/slack/commands` and `/slack/interactions`.
+
+func main() {
+	bot := slacker.NewClient(
+		os.Getenv("SLACK_BOT_TOKEN"),
+		"",
+		slacker.WithTransportMode(slacker.TransportModeHTTP),
+		slacker.WithSigningSecret(os.Getenv("SLACK_SIGNING_SECRET")),
+		slacker.WithHTTPAddress(":3000"),
+	)
+
+	bot.AddCommand(&slacker.CommandDefinition{
+		Command: "ping",
+		Handler: func(ctx *slacker.CommandContext) {
+			ctx.Response().Reply("pong")
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err := bot.Listen(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
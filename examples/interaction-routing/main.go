@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/shomali11/slacker/v2"
+	"github.com/slack-go/slack"
+)
+
+// Routing the approve and deny buttons of access requests by action ID, the ID of the
+// request being carried in the action ID itself.
+
+func main() {
+	bot := slacker.NewClient(os.Getenv("SLACK_BOT_TOKEN"), os.Getenv("SLACK_APP_TOKEN"))
+
+	bot.AddCommand(&slacker.CommandDefinition{
+		Command:     "request access <system>",
+		Description: "Requests access to a system",
+		Handler: func(ctx *slacker.CommandContext) {
+			requestID := ctx.Event().TimeStamp
+			system := ctx.Request().Param("system")
+
+			approveBtn := slack.NewButtonBlockElement("approve:"+requestID, system, slack.NewTextBlockObject(slack.PlainTextType, "Approve", false, false))
+			approveBtn.Style = slack.StylePrimary
+			denyBtn := slack.NewButtonBlockElement("deny:"+requestID, system, slack.NewTextBlockObject(slack.PlainTextType, "Deny", false, false))
+			denyBtn.Style = slack.StyleDanger
+
+			ctx.Response().ReplyBlocks([]slack.Block{
+				slack.NewSectionBlock(slack.NewTextBlockObject(slack.MarkdownType, fmt.Sprintf("<@%s> requests access to *%s*", ctx.Event().UserID, system), false, false), nil, nil),
+				slack.NewActionBlock("access-request", approveBtn, denyBtn),
+			})
+		},
+	})
+
+	bot.AddInteraction(&slacker.InteractionDefinition{
+		ActionID:  "approve:",
+		MatchMode: slacker.MatchPrefix,
+		Type:      slack.InteractionTypeBlockActions,
+		Handler: func(ctx *slacker.InteractionContext) {
+			requestID := ctx.Captures()[0]
+			text := fmt.Sprintf("Request %s to access %s approved by <@%s>", requestID, ctx.Action().Value, ctx.Callback().User.ID)
+			ctx.Response().Reply(text, slacker.WithReplace(ctx.Callback().Message.Timestamp))
+		},
+	})
+
+	bot.AddInteraction(&slacker.InteractionDefinition{
+		InteractionID: "access-request",
+		ActionID:      `^deny:(?P<request>[0-9.]+)$`,
+		MatchMode:     slacker.MatchRegex,
+		Type:          slack.InteractionTypeBlockActions,
+		Handler: func(ctx *slacker.InteractionContext) {
+			text := fmt.Sprintf("Request %s to access %s denied by <@%s>", ctx.Capture("request"), ctx.Action().Value, ctx.Callback().User.ID)
+			ctx.Response().Reply(text, slacker.WithReplace(ctx.Callback().Message.Timestamp))
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err := bot.Listen(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
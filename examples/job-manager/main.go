@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/shomali11/slacker/v2"
+)
+
+// Showcase the ability to add, remove, pause, resume and trigger jobs while the bot listens
+
+func main() {
+	bot := slacker.NewClient(os.Getenv("SLACK_BOT_TOKEN"), os.Getenv("SLACK_APP_TOKEN"))
+
+	bot.AddJob(&slacker.JobDefinition{
+		CronExpression: "0 9 * * 1-5",
+		Name:           "standup",
+		Description:    "Reminds the team of the standup every weekday",
+		Handler: func(ctx *slacker.JobContext) {
+			ctx.Response().Post("#test", "Standup time!")
+		},
+	})
+
+	// Schedule a job from a command, here every N minutes
+	bot.AddCommand(&slacker.CommandDefinition{
+		Command: "remind every <minutes>",
+		Handler: func(ctx *slacker.CommandContext) {
+			minutes := ctx.Request().IntegerParam("minutes", 60)
+			err := bot.JobManager().Add(&slacker.JobDefinition{
+				CronExpression: fmt.Sprintf("*/%d * * * *", minutes),
+				Name:           "reminder",
+				Handler: func(ctx *slacker.JobContext) {
+					ctx.Response().Post("#test", "Reminder!")
+				},
+			})
+			if err != nil {
+				ctx.Response().ReplyError(err)
+				return
+			}
+
+			status, _ := bot.JobManager().Status("reminder")
+			ctx.Response().Reply("Next reminder at " + status.Next.String())
+		},
+	})
+
+	bot.AddCommand(&slacker.CommandDefinition{
+		Command: "stop reminding",
+		Handler: func(ctx *slacker.CommandContext) {
+			if err := bot.JobManager().Remove("reminder"); err != nil {
+				ctx.Response().ReplyError(err)
+				return
+			}
+			ctx.Response().Reply("No more reminders")
+		},
+	})
+
+	// Add `jobs list`, `jobs run <name>`, `jobs pause <name>` and `jobs resume <name>`,
+	// restricted to workspace admins and owners
+	bot.AddJobCommands(slacker.RoleAdmin, slacker.RoleOwner)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err := bot.Listen(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/shomali11/slacker/v2"
+)
+
+// Showcase the ability to keep slow jobs from overlapping, time them out and retry them when they fail
+
+func main() {
+	bot := slacker.NewClient(os.Getenv("SLACK_BOT_TOKEN"), os.Getenv("SLACK_APP_TOKEN"))
+
+	// Run every minute, skipping runs while the previous one is still running.
+	// Each attempt is canceled after 30 seconds and failed attempts are retried
+	// twice, 5 then 10 seconds later.
+	bot.AddJob(&slacker.JobDefinition{
+		CronExpression: "*/1 * * * *",
+		Name:           "HealthCheck",
+		Description:    "Checks the health of the service every minute",
+		Overlap:        slacker.OverlapSkip,
+		Timeout:        30 * time.Second,
+		Retries:        2,
+		RetryBackoff:   5 * time.Second,
+		Handler: slacker.JobHandlerWithError(func(ctx *slacker.JobContext) error {
+			request, err := http.NewRequestWithContext(ctx.Context(), http.MethodGet, "https://example.com/health", nil)
+			if err != nil {
+				return err
+			}
+
+			response, err := http.DefaultClient.Do(request)
+			if err != nil {
+				return err
+			}
+			defer response.Body.Close()
+
+			if response.StatusCode != http.StatusOK {
+				ctx.Response().Post("#test", "Service unhealthy: "+response.Status)
+			}
+			return nil
+		}),
+	})
+
+	// Run every hour, up to 5 minutes late so that jobs scheduled at the same
+	// time do not all hit the API at once
+	bot.AddJob(&slacker.JobDefinition{
+		CronExpression: "0 * * * *",
+		Name:           "Report",
+		Description:    "Posts a report every hour",
+		Overlap:        slacker.OverlapDelay,
+		Jitter:         5 * time.Minute,
+		Handler: func(ctx *slacker.JobContext) {
+			ctx.Response().Post("#test", "Hourly report")
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err := bot.Listen(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"time"
+
+	"github.com/shomali11/slacker/v2"
+)
+
+// Caching channel and user lookups, and only performing them when a handler needs them.
+// Cached entries are invalidated when Slack reports `user_change` or `channel_rename` events.
+
+func main() {
+	bot := slacker.NewClient(
+		os.Getenv("SLACK_BOT_TOKEN"),
+		os.Getenv("SLACK_APP_TOKEN"),
+		slacker.WithCache(slacker.NewLRUCache(5000, time.Hour)),
+		slacker.WithLazyLookups(true),
+	)
+
+	bot.AddCommand(&slacker.CommandDefinition{
+		Command: "ping",
+		Handler: func(ctx *slacker.CommandContext) {
+			// No lookups are performed for this command
+			ctx.Response().Reply("pong")
+		},
+	})
+
+	bot.AddCommand(&slacker.CommandDefinition{
+		Command: "whoami",
+		Handler: func(ctx *slacker.CommandContext) {
+			profile := ctx.Event().GetUserProfile()
+			channel := ctx.Event().GetChannel()
+			if profile == nil || channel == nil {
+				ctx.Response().Reply("I could not look you up :(")
+				return
+			}
+			ctx.Response().Reply("You are " + profile.RealName + " in #" + channel.Name)
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err := bot.Listen(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"os"
+
+	"github.com/shomali11/slacker/v2"
+)
+
+// Recording command, interaction and job metrics, exposed to Prometheus on http://localhost:9090/metrics.
+// Implement slacker.MetricsRecorder to use your own Prometheus or OpenTelemetry exporter instead.
+
+func main() {
+	recorder := slacker.NewPrometheusRecorder()
+
+	bot := slacker.NewClient(
+		os.Getenv("SLACK_BOT_TOKEN"),
+		os.Getenv("SLACK_APP_TOKEN"),
+		slacker.WithMetricsRecorder(recorder),
+	)
+
+	bot.AddCommand(&slacker.CommandDefinition{
+		Command: "ping",
+		Handler: func(ctx *slacker.CommandContext) {
+			ctx.Response().Reply("pong")
+		},
+	})
+
+	go func() {
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", recorder)
+		log.Fatal(http.ListenAndServe(":9090", mux))
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err := bot.Listen(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
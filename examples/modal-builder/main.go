@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/shomali11/slacker/v2"
+	"github.com/slack-go/slack"
+)
+
+// Opening a leave request modal from the `/leave` slash command, then handling its
+// submission with the values bound to a struct and validated field by field.
+// This assumes you have the slash command `/leave` defined for your app.
+
+type leaveRequest struct {
+	Reason   string    `slacker:"reason"`
+	Start    time.Time `slacker:"start"`
+	Days     int       `slacker:"days"`
+	Kind     string    `slacker:"kind"`
+	Notify   []string  `slacker:"notify"`
+	Approver string    `slacker:"approver"`
+}
+
+// Validate shows errors under the inputs, keeping the modal open
+func (r *leaveRequest) Validate() slacker.ViewErrors {
+	errors := slacker.ViewErrors{}
+	if r.Days < 1 || r.Days > 30 {
+		errors["days"] = "Requests are between 1 and 30 days"
+	}
+
+	if r.Start.Before(time.Now().Truncate(24 * time.Hour)) {
+		errors["start"] = "Requests cannot start in the past"
+	}
+	return errors
+}
+
+func main() {
+	bot := slacker.NewClient(os.Getenv("SLACK_BOT_TOKEN"), os.Getenv("SLACK_APP_TOKEN"))
+
+	bot.AddCommand(&slacker.CommandDefinition{
+		Command:     "leave",
+		Description: "Requests leave",
+		Handler: func(ctx *slacker.CommandContext) {
+			modal := slacker.NewModal("leave-request", "Leave request").
+				Submit("Request").
+				Close("Cancel").
+				Text("Your manager is notified once you submit the request.").
+				Input("start", "Start", slacker.InputTypeDate, slacker.WithInitialValue(time.Now().Format("2006-01-02"))).
+				Input("days", "Days", slacker.InputTypeNumber, slacker.WithInitialValue("1")).
+				Input("kind", "Kind", slacker.InputTypeRadio, slacker.WithChoices("Vacation", "Sick", "Parental"), slacker.WithInitialValue("Vacation")).
+				Input("reason", "Reason", slacker.InputTypeMultilineText, slacker.WithOptional()).
+				Input("notify", "Notify", slacker.InputTypeCheckboxes, slacker.WithChoices("Team", "HR"), slacker.WithOptional()).
+				Input("approver", "Approver", slacker.InputTypeUser, slacker.WithHint("Defaults to your manager")).
+				Build()
+
+			_, err := ctx.OpenView(modal)
+			if err != nil {
+				ctx.Response().ReplyError(err)
+			}
+		},
+	})
+
+	bot.AddInteraction(&slacker.InteractionDefinition{
+		InteractionID: "leave-request",
+		Type:          slack.InteractionTypeViewSubmission,
+		Handler: slacker.TypedViewSubmissionHandler(func(ctx *slacker.InteractionContext, request *leaveRequest) {
+			text := fmt.Sprintf("<@%s> requests %d days of %s leave from %s", ctx.Callback().User.ID, request.Days, request.Kind, request.Start.Format("Jan 2"))
+			ctx.Response().Post(request.Approver, text)
+		}),
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err := bot.Listen(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"time"
+
+	"github.com/shomali11/slacker/v2"
+)
+
+// Limiting how often users can invoke commands and interactions
+
+func main() {
+	bot := slacker.NewClient(os.Getenv("SLACK_BOT_TOKEN"), os.Getenv("SLACK_APP_TOKEN"))
+
+	// Every user can run 10 commands per minute across the whole bot
+	userLimiter := slacker.NewRateLimiter(10, time.Minute)
+	bot.AddCommandMiddleware(userLimiter.CommandMiddleware())
+	bot.AddInteractionMiddleware(userLimiter.InteractionMiddleware())
+
+	// The expensive command can only run twice per hour in every channel
+	channelLimiter := slacker.NewRateLimiter(
+		2,
+		time.Hour,
+		slacker.WithRateLimitKey(slacker.RateLimitKeyChannel|slacker.RateLimitKeyCommand),
+		slacker.WithRateLimitMessage("This report was generated recently, please try again later."),
+	)
+
+	bot.AddCommand(&slacker.CommandDefinition{
+		Command:     "report",
+		Description: "Generate an expensive report",
+		Middlewares: []slacker.CommandMiddlewareHandler{channelLimiter.CommandMiddleware()},
+		Handler: func(ctx *slacker.CommandContext) {
+			ctx.Response().Reply("Here is your report!")
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err := bot.Listen(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
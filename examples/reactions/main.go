@@ -0,0 +1,38 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/shomali11/slacker/v2"
+)
+
+// Filing a ticket when a message gets a :ticket: reaction in the on-call channel, replying in its thread.
+// Remember to subscribe to the `reaction_added` bot event and to add the `channels:history` scope.
+
+func main() {
+	bot := slacker.NewClient(os.Getenv("SLACK_BOT_TOKEN"), os.Getenv("SLACK_APP_TOKEN"))
+
+	bot.AddReaction(&slacker.ReactionDefinition{
+		Emoji:       "ticket",
+		Channels:    []string{os.Getenv("ONCALL_CHANNEL_ID")},
+		Description: "Files a ticket for the message",
+		Handler: func(ctx *slacker.ReactionContext) {
+			message := ctx.Message()
+			ticket := fmt.Sprintf("Reported by <@%s>: %s (%s)", message.UserID, message.Text, message.Permalink)
+			ctx.Logger().Infof("filing ticket %s\n", ticket)
+
+			ctx.Response().Reply(fmt.Sprintf("Ticket filed by <@%s> :white_check_mark:", ctx.Event().User))
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err := bot.Listen(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
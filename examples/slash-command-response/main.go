@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"time"
+
+	"github.com/shomali11/slacker/v2"
+	"github.com/slack-go/slack"
+)
+
+// Replying to slash commands through their response URL, which works in channels
+// the bot is not a member of, and acknowledging them right away with a message.
+// This assumes you have the slash command `/deploy` defined for your app.
+
+func main() {
+	bot := slacker.NewClient(os.Getenv("SLACK_BOT_TOKEN"), os.Getenv("SLACK_APP_TOKEN"))
+
+	bot.AddCommand(&slacker.CommandDefinition{
+		Command:     "deploy <service>",
+		Description: "Deploys a service",
+		Handler: func(ctx *slacker.CommandContext) {
+			service := ctx.Request().Param("service")
+
+			// Shown to the user right away, while the deployment runs
+			ctx.Ack(&slack.Msg{
+				Text:         "Deploying " + service + "...",
+				ResponseType: slack.ResponseTypeEphemeral,
+			})
+
+			time.Sleep(5 * time.Second)
+
+			ctx.Response().Reply("Deployed " + service + " :rocket:")
+		},
+	})
+
+	bot.AddCommand(&slacker.CommandDefinition{
+		Command:     "status",
+		Description: "Shows the deployment status, only to you",
+		Handler: func(ctx *slacker.CommandContext) {
+			ctx.Response().Reply("Checking...", slacker.WithEphemeral())
+			ctx.Response().Reply("All services are up", slacker.WithEphemeral(), slacker.WithReplaceOriginal())
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err := bot.Listen(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
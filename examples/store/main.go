@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/shomali11/slacker/v2"
+)
+
+// Counting the greetings of each user in a file-backed store, so that counts survive restarts,
+// and remembering the last greeting of the day for 24 hours.
+
+func main() {
+	store, err := slacker.NewFileStore("slacker-store.json")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	bot := slacker.NewClient(
+		os.Getenv("SLACK_BOT_TOKEN"),
+		os.Getenv("SLACK_APP_TOKEN"),
+		slacker.WithStore(store),
+		slacker.WithConversationStore(slacker.NewStoreConversationStore(store)),
+	)
+
+	greetings := slacker.NewScopedStore(bot.Store(), "greetings")
+
+	bot.AddCommand(&slacker.CommandDefinition{
+		Command:     "hello",
+		Description: "Counts your greetings",
+		Handler: slacker.CommandHandlerWithError(func(ctx *slacker.CommandContext) error {
+			var count int
+			if _, err := ctx.UserStore().GetJSON(ctx.Context(), "greetings", &count); err != nil {
+				return err
+			}
+
+			count++
+			if err := ctx.UserStore().SetJSON(ctx.Context(), "greetings", count, 0); err != nil {
+				return err
+			}
+
+			if err := greetings.Set(ctx.Context(), "last", []byte(ctx.Event().UserID), 24*time.Hour); err != nil {
+				return err
+			}
+
+			ctx.Response().Reply(fmt.Sprintf("Hello! That's %d greeting(s) so far", count))
+			return nil
+		}),
+	})
+
+	bot.AddCommand(&slacker.CommandDefinition{
+		Command:     "last",
+		Description: "Tells who greeted last today",
+		Handler: func(ctx *slacker.CommandContext) {
+			last, ok, err := greetings.Get(ctx.Context(), "last")
+			if err != nil || !ok {
+				ctx.Response().Reply("Nobody greeted me today")
+				return
+			}
+			ctx.Response().Reply(fmt.Sprintf("<@%s> greeted me last", last))
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err = bot.Listen(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
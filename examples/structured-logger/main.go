@@ -0,0 +1,40 @@
+//go:build go1.21
+
+package main
+
+import (
+	"context"
+	"log"
+	"log/slog"
+	"os"
+
+	"github.com/shomali11/slacker/v2"
+)
+
+// Logging with log/slog. Context loggers include the channel ID, user ID and command name.
+
+func main() {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	bot := slacker.NewClient(
+		os.Getenv("SLACK_BOT_TOKEN"),
+		os.Getenv("SLACK_APP_TOKEN"),
+		slacker.WithStructuredLogger(slacker.NewSlogLogger(logger)),
+	)
+
+	bot.AddCommand(&slacker.CommandDefinition{
+		Command: "ping",
+		Handler: func(ctx *slacker.CommandContext) {
+			ctx.StructuredLogger().Info("replying to ping", "text", ctx.Event().Text)
+			ctx.Response().Reply("pong")
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err := bot.Listen(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
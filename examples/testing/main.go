@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/shomali11/slacker/v2"
+	"github.com/shomali11/slacker/v2/slackertest"
+)
+
+// Running commands without a Slack workspace using the slackertest harness.
+// The same approach can be used inside your own `_test.go` files.
+
+func main() {
+	harness := slackertest.NewHarness()
+	defer harness.Close()
+
+	bot := harness.Bot()
+	bot.AddCommandMiddleware(func(next slacker.CommandHandler) slacker.CommandHandler {
+		return func(ctx *slacker.CommandContext) {
+			ctx.Response().Reply("handling " + ctx.Definition().Command)
+			next(ctx)
+		}
+	})
+
+	bot.AddCommand(&slacker.CommandDefinition{
+		Command: "echo {word}",
+		Handler: func(ctx *slacker.CommandContext) {
+			ctx.Response().Reply(ctx.Request().Param("word"), slacker.WithInThread(true))
+		},
+	})
+
+	harness.SendMessage("C123", "U123", "echo hello")
+
+	messages := harness.Messages()
+	if len(messages) != 2 || messages[1].Text != "hello" {
+		log.Fatalf("unexpected messages %+v", messages)
+	}
+
+	for _, message := range messages {
+		fmt.Printf("%s %s thread=%q %q\n", message.Method, message.Channel, message.ThreadTimeStamp, message.Text)
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"time"
+
+	"github.com/shomali11/slacker/v2"
+)
+
+// Tracing events with a tracer logging the duration of every span. Implement slacker.Tracer on top of
+// an OpenTelemetry tracer to export the spans instead.
+
+func main() {
+	bot := slacker.NewClient(
+		os.Getenv("SLACK_BOT_TOKEN"),
+		os.Getenv("SLACK_APP_TOKEN"),
+		slacker.WithTracer(&LogTracer{}),
+	)
+
+	bot.AddCommand(&slacker.CommandDefinition{
+		Command: "ping",
+		Handler: func(ctx *slacker.CommandContext) {
+			_, span := slacker.StartSpan(ctx.Context(), "compute pong")
+			time.Sleep(100 * time.Millisecond)
+			span.End()
+
+			ctx.Response().Reply("pong")
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err := bot.Listen(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+type LogTracer struct{}
+
+func (t *LogTracer) Start(ctx context.Context, name string, attributes ...slacker.Attribute) (context.Context, slacker.Span) {
+	return ctx, &LogSpan{name: name, attributes: attributes, start: time.Now()}
+}
+
+type LogSpan struct {
+	name       string
+	attributes []slacker.Attribute
+	start      time.Time
+	err        error
+}
+
+func (s *LogSpan) SetAttributes(attributes ...slacker.Attribute) {
+	s.attributes = append(s.attributes, attributes...)
+}
+
+func (s *LogSpan) RecordError(err error) {
+	s.err = err
+}
+
+func (s *LogSpan) End() {
+	log.Printf("%s took %s %v error=%v", s.name, time.Since(s.start), s.attributes, s.err)
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"time"
+
+	"github.com/shomali11/slacker/v2"
+)
+
+// Dispatching events to 4 workers through a queue of up to 100 events. Events of a channel are handled
+// in order, and users are told the bot is busy when the queue is full.
+
+func main() {
+	bot := slacker.NewClient(
+		os.Getenv("SLACK_BOT_TOKEN"),
+		os.Getenv("SLACK_APP_TOKEN"),
+		slacker.WithWorkerPool(4, 100),
+		slacker.WithOverflowPolicy(slacker.OverflowPolicyReplyBusy),
+		slacker.WithChannelSerialization(true),
+		slacker.WithBusyMessage("Too many requests at the moment, try again shortly :hourglass:"),
+	)
+
+	bot.AddCommand(&slacker.CommandDefinition{
+		Command: "work",
+		Handler: func(ctx *slacker.CommandContext) {
+			time.Sleep(time.Second)
+			ctx.Response().Reply("Done!")
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err := bot.Listen(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/shomali11/slacker/v2"
+)
+
+// Exposing a Workflow Builder custom step computing the end date of a leave request.
+// This assumes the app manifest declares the function, with its inputs and outputs:
+//
+//	"functions": {
+//	    "leave_end_date": {
+//	        "title": "Compute leave end date",
+//	        "input_parameters": {
+//	            "start": {"type": "slack#/types/timestamp", "title": "Start"},
+//	            "days": {"type": "integer", "title": "Days"}
+//	        },
+//	        "output_parameters": {
+//	            "end": {"type": "string", "title": "End"}
+//	        }
+//	    }
+//	}
+
+type leaveInputs struct {
+	Start time.Time `slacker:"start"`
+	Days  int       `slacker:"days"`
+}
+
+func main() {
+	bot := slacker.NewClient(os.Getenv("SLACK_BOT_TOKEN"), os.Getenv("SLACK_APP_TOKEN"))
+
+	bot.AddFunction(&slacker.FunctionDefinition{
+		CallbackID:  "leave_end_date",
+		Description: "Computes the end date of a leave request",
+		Handler: slacker.FunctionHandlerWithError(func(ctx *slacker.FunctionContext) error {
+			var inputs leaveInputs
+			if err := ctx.BindInputs(&inputs); err != nil {
+				return err
+			}
+
+			if inputs.Days < 1 {
+				return ctx.Fail(fmt.Sprintf("%d is not a valid number of days", inputs.Days))
+			}
+
+			end := inputs.Start.AddDate(0, 0, inputs.Days-1)
+			return ctx.Complete(map[string]any{"end": end.Format("Monday, January 2")})
+		}),
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err := bot.Listen(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
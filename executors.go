@@ -1,39 +1,237 @@
 package slacker
 
-func executeCommand(ctx *CommandContext, handler CommandHandler, middlewares ...CommandMiddlewareHandler) {
+func executeCommand(ctx *CommandContext, reporter *errorReporter, handler CommandHandler, middlewares ...CommandMiddlewareHandler) {
 	if handler == nil {
 		return
 	}
 
+	var chain *spanChain
+	if isTraced(ctx.ctx) {
+		chain = newSpanChain(ctx.ctx)
+		handler = traceChained(chain, handler, spanInvoke)
+	}
+
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](handler)
+		if chain != nil {
+			handler = traceChained(chain, handler, spanMiddleware, Attr(attributeIndex, i))
+		}
+	}
+
+	defer reporter.recoverCommand(ctx)
+	handler(ctx)
+
+	if ctx.err != nil {
+		reporter.reportCommand(ctx, newCommandError(ctx, ctx.err))
+	}
+}
+
+func executeInteraction(ctx *InteractionContext, reporter *errorReporter, handler InteractionHandler, middlewares ...InteractionMiddlewareHandler) {
+	if handler == nil {
+		return
+	}
+
+	var chain *spanChain
+	if isTraced(ctx.ctx) {
+		chain = newSpanChain(ctx.ctx)
+		handler = traceChained(chain, handler, spanInvoke)
+	}
+
 	for i := len(middlewares) - 1; i >= 0; i-- {
 		handler = middlewares[i](handler)
+		if chain != nil {
+			handler = traceChained(chain, handler, spanMiddleware, Attr(attributeIndex, i))
+		}
 	}
 
+	defer reporter.recoverInteraction(ctx)
 	handler(ctx)
+
+	if ctx.err != nil {
+		reporter.reportInteraction(ctx, newInteractionError(ctx, ctx.err))
+	}
 }
 
-func executeInteraction(ctx *InteractionContext, handler InteractionHandler, middlewares ...InteractionMiddlewareHandler) {
+func executeReaction(ctx *ReactionContext, reporter *errorReporter, handler ReactionHandler, middlewares ...ReactionMiddlewareHandler) {
 	if handler == nil {
 		return
 	}
 
+	var chain *spanChain
+	if isTraced(ctx.ctx) {
+		chain = newSpanChain(ctx.ctx)
+		handler = traceChained(chain, handler, spanInvoke)
+	}
+
 	for i := len(middlewares) - 1; i >= 0; i-- {
 		handler = middlewares[i](handler)
+		if chain != nil {
+			handler = traceChained(chain, handler, spanMiddleware, Attr(attributeIndex, i))
+		}
 	}
 
+	defer reporter.recoverReaction(ctx)
 	handler(ctx)
+
+	if ctx.err != nil {
+		reporter.reportReaction(ctx, newReactionError(ctx, ctx.err))
+	}
 }
 
-func executeJob(ctx *JobContext, handler JobHandler, middlewares ...JobMiddlewareHandler) func() {
+func executeEvent(ctx *EventContext, reporter *errorReporter, handler EventHandler, middlewares ...EventMiddlewareHandler) {
+	if handler == nil {
+		return
+	}
+
+	var chain *spanChain
+	if isTraced(ctx.ctx) {
+		chain = newSpanChain(ctx.ctx)
+		handler = traceChained(chain, handler, spanInvoke)
+	}
+
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](handler)
+		if chain != nil {
+			handler = traceChained(chain, handler, spanMiddleware, Attr(attributeIndex, i))
+		}
+	}
+
+	defer reporter.recoverEvent(ctx)
+	handler(ctx)
+
+	if ctx.err != nil {
+		reporter.report(newEventError(ctx, ctx.err))
+	}
+}
+
+func executeAppHome(ctx *AppHomeContext, reporter *errorReporter, handler AppHomeHandler, middlewares ...AppHomeMiddlewareHandler) {
+	if handler == nil {
+		return
+	}
+
+	var chain *spanChain
+	if isTraced(ctx.ctx) {
+		chain = newSpanChain(ctx.ctx)
+		handler = traceChained(chain, handler, spanInvoke)
+	}
+
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](handler)
+		if chain != nil {
+			handler = traceChained(chain, handler, spanMiddleware, Attr(attributeIndex, i))
+		}
+	}
+
+	defer reporter.recoverAppHome(ctx)
+	handler(ctx)
+
+	if ctx.err != nil {
+		reporter.report(newAppHomeError(ctx, ctx.err))
+	}
+}
+
+func executeConversation(ctx *ConversationContext, reporter *errorReporter, handler ConversationHandler, middlewares ...ConversationMiddlewareHandler) {
+	if handler == nil {
+		return
+	}
+
+	var chain *spanChain
+	if isTraced(ctx.ctx) {
+		chain = newSpanChain(ctx.ctx)
+		handler = traceChained(chain, handler, spanInvoke)
+	}
+
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](handler)
+		if chain != nil {
+			handler = traceChained(chain, handler, spanMiddleware, Attr(attributeIndex, i))
+		}
+	}
+
+	defer reporter.recoverConversation(ctx)
+	handler(ctx)
+
+	if ctx.err != nil {
+		reporter.reportConversation(ctx, newConversationError(ctx, ctx.err))
+	}
+}
+
+func executeOptions(ctx *OptionsContext, reporter *errorReporter, handler OptionsHandler, middlewares ...OptionsMiddlewareHandler) {
+	if handler == nil {
+		return
+	}
+
+	var chain *spanChain
+	if isTraced(ctx.ctx) {
+		chain = newSpanChain(ctx.ctx)
+		handler = traceChained(chain, handler, spanInvoke)
+	}
+
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](handler)
+		if chain != nil {
+			handler = traceChained(chain, handler, spanMiddleware, Attr(attributeIndex, i))
+		}
+	}
+
+	defer reporter.recoverOptions(ctx)
+	handler(ctx)
+
+	if ctx.err != nil {
+		reporter.report(newOptionsError(ctx, ctx.err))
+	}
+}
+
+func executeFunction(ctx *FunctionContext, reporter *errorReporter, handler FunctionHandler, middlewares ...FunctionMiddlewareHandler) {
+	if handler == nil {
+		return
+	}
+
+	var chain *spanChain
+	if isTraced(ctx.ctx) {
+		chain = newSpanChain(ctx.ctx)
+		handler = traceChained(chain, handler, spanInvoke)
+	}
+
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](handler)
+		if chain != nil {
+			handler = traceChained(chain, handler, spanMiddleware, Attr(attributeIndex, i))
+		}
+	}
+
+	defer reporter.recoverFunction(ctx)
+	handler(ctx)
+
+	if ctx.err != nil {
+		reporter.reportFunction(ctx, newFunctionError(ctx, ctx.err))
+	}
+}
+
+func executeJob(ctx *JobContext, reporter *errorReporter, handler JobHandler, middlewares ...JobMiddlewareHandler) func() {
 	if handler == nil {
 		return func() {}
 	}
 
+	var chain *spanChain
+	if isTraced(ctx.ctx) {
+		chain = newSpanChain(ctx.ctx)
+		handler = traceChained(chain, handler, spanInvoke)
+	}
+
 	for i := len(middlewares) - 1; i >= 0; i-- {
 		handler = middlewares[i](handler)
+		if chain != nil {
+			handler = traceChained(chain, handler, spanMiddleware, Attr(attributeIndex, i))
+		}
 	}
 
 	return func() {
+		defer reporter.recoverJob(ctx)
 		handler(ctx)
+
+		if ctx.err != nil {
+			reporter.report(newJobError(ctx, ctx.err))
+		}
 	}
 }
@@ -0,0 +1,258 @@
+package slacker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"time"
+
+	"github.com/slack-go/slack/slackevents"
+)
+
+const (
+	// FunctionExecuted is the type of the event sent when a workflow runs a custom step of the app
+	FunctionExecuted = slackevents.EventsAPIType("function_executed")
+
+	spanSlackPrefix        = "slack."
+	methodCompleteSuccess  = "functions.completeSuccess"
+	methodCompleteError    = "functions.completeError"
+	functionsNotSupported  = "functions can only be completed by function handlers or Slacker"
+	invalidFunctionInput   = "invalid input `%s`: %w"
+	functionResponseFailed = "%s failed: %s"
+	functionsClientTimeout = 30 * time.Second
+)
+
+var errFunctionsNotSupported = errors.New(functionsNotSupported)
+
+// FunctionExecutedEvent is sent when a workflow runs a custom step of the app
+type FunctionExecutedEvent struct {
+	Type string `json:"type"`
+
+	// Function is the custom step being run
+	Function ExecutedFunction `json:"function"`
+
+	// Inputs are the values of the step's input parameters, keyed by name
+	Inputs map[string]any `json:"inputs"`
+
+	// FunctionExecutionID identifies the run, used to complete or fail it
+	FunctionExecutionID string `json:"function_execution_id"`
+
+	// WorkflowExecutionID identifies the run of the workflow
+	WorkflowExecutionID string `json:"workflow_execution_id"`
+
+	EventTimeStamp string `json:"event_ts"`
+
+	// BotAccessToken is a token scoped to the run, valid until it completes
+	BotAccessToken string `json:"bot_access_token"`
+}
+
+// ExecutedFunction describes the custom step run by a workflow
+type ExecutedFunction struct {
+	ID          string `json:"id"`
+	CallbackID  string `json:"callback_id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	AppID       string `json:"app_id"`
+}
+
+// FunctionDefinition structure contains definition of a Workflow Builder custom
+// step. The step itself, its inputs and outputs, is declared in the app manifest.
+type FunctionDefinition struct {
+	// CallbackID matches the callback ID of the function in the app manifest
+	CallbackID string
+
+	Description string
+	Middlewares []FunctionMiddlewareHandler
+
+	// Handler runs the step, completing it with FunctionContext.Complete or
+	// failing it with FunctionContext.Fail. Steps left pending can be completed
+	// later with Slacker.CompleteFunction, for instance once a user approved.
+	// Errors and panics fail the step.
+	Handler FunctionHandler
+}
+
+// newFunction creates a new bot function object
+func newFunction(definition *FunctionDefinition) *Function {
+	return &Function{definition: definition}
+}
+
+// Function structure contains the bot's Workflow Builder custom step and its handler
+type Function struct {
+	definition *FunctionDefinition
+}
+
+// Definition returns the function definition
+func (f *Function) Definition() *FunctionDefinition {
+	return f.definition
+}
+
+// CompleteFunction completes a run of a custom step with its outputs, keyed by name
+func (s *Slacker) CompleteFunction(ctx context.Context, functionExecutionID string, outputs map[string]any) error {
+	return s.newFunctionWriter(ctx, s.logger, "").CompleteFunction(functionExecutionID, outputs)
+}
+
+// FailFunction fails a run of a custom step, showing the message in the workflow's activity
+func (s *Slacker) FailFunction(ctx context.Context, functionExecutionID string, message string) error {
+	return s.newFunctionWriter(ctx, s.logger, "").FailFunction(functionExecutionID, message)
+}
+
+// newFunctionWriter creates a writer able to complete and fail functions, with
+// the token of the run if any, or the bot token
+func (s *Slacker) newFunctionWriter(ctx context.Context, logger StructuredLogger, token string) *Writer {
+	writer := newWriter(ctx, logger, s.metrics, s.slackClient)
+	writer.functions = s.functionsClient.withToken(token)
+	return writer
+}
+
+// parseFunctionEvent parses an Events API payload carrying a `function_executed`
+// event. slack-go does not know about function events and fails to parse them,
+// so they are parsed from the raw inner event instead. It returns false for
+// any other payload.
+func parseFunctionEvent(payload json.RawMessage) (slackevents.EventsAPIEvent, bool) {
+	callbackEvent := &slackevents.EventsAPICallbackEvent{}
+	if err := json.Unmarshal(payload, callbackEvent); err != nil || callbackEvent.Type != slackevents.CallbackEvent || callbackEvent.InnerEvent == nil {
+		return slackevents.EventsAPIEvent{}, false
+	}
+
+	functionEvent := &FunctionExecutedEvent{}
+	if err := json.Unmarshal(*callbackEvent.InnerEvent, functionEvent); err != nil || functionEvent.Type != string(FunctionExecuted) {
+		return slackevents.EventsAPIEvent{}, false
+	}
+
+	return slackevents.EventsAPIEvent{
+		Token:        callbackEvent.Token,
+		TeamID:       callbackEvent.TeamID,
+		Type:         callbackEvent.Type,
+		APIAppID:     callbackEvent.APIAppID,
+		EnterpriseID: callbackEvent.EnterpriseID,
+		Data:         callbackEvent,
+		InnerEvent:   slackevents.EventsAPIInnerEvent{Type: functionEvent.Type, Data: functionEvent},
+	}, true
+}
+
+// handleFunctionEvent runs the function of the event, returning false if there is none
+func (s *Slacker) handleFunctionEvent(ctx context.Context, event *FunctionExecutedEvent) bool {
+	function, ok := s.functions[event.Function.CallbackID]
+	if !ok {
+		return false
+	}
+
+	definition := function.Definition()
+	s.metrics.functionExecuted(definition.CallbackID)
+	defer s.metrics.handlerDone(ContextTypeFunction, definition.CallbackID, time.Now())
+
+	middlewares := make([]FunctionMiddlewareHandler, 0)
+	middlewares = append(middlewares, s.functionMiddlewares...)
+	middlewares = append(middlewares, definition.Middlewares...)
+
+	logger := s.logger.With(logKeyFunction, definition.CallbackID, logKeyFunctionExecution, event.FunctionExecutionID)
+	spanCtx, span := StartSpan(ctx, spanHandler)
+	functionCtx := newFunctionContext(spanCtx, logger, s.slackClient, s.newFunctionWriter(spanCtx, logger, event.BotAccessToken), event, definition)
+	executeFunction(functionCtx, s.errorReporter, definition.Handler, middlewares...)
+	endSpan(span, functionCtx.err)
+	return true
+}
+
+// newFunctionsClient creates a new client of the function methods, which slack-go does not support
+func newFunctionsClient(apiURL string, token string) *functionsClient {
+	return &functionsClient{httpClient: &http.Client{Timeout: functionsClientTimeout}, apiURL: apiURL, token: token}
+}
+
+// functionsClient calls the Slack API methods completing functions
+type functionsClient struct {
+	httpClient *http.Client
+	apiURL     string
+	token      string
+}
+
+// withToken returns a client calling the methods with the token, or this client without token
+func (c *functionsClient) withToken(token string) *functionsClient {
+	if len(token) == 0 {
+		return c
+	}
+
+	client := *c
+	client.token = token
+	return &client
+}
+
+// call posts the request as JSON to the method, failing if Slack reports an error
+func (c *functionsClient) call(ctx context.Context, method string, request any) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, c.apiURL+method, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	httpRequest.Header.Set("Content-Type", "application/json; charset=utf-8")
+	httpRequest.Header.Set("Authorization", "Bearer "+c.token)
+
+	httpResponse, err := c.httpClient.Do(httpRequest)
+	if err != nil {
+		return err
+	}
+	defer httpResponse.Body.Close()
+
+	var response struct {
+		Ok    bool   `json:"ok"`
+		Error string `json:"error"`
+	}
+	if err := json.NewDecoder(httpResponse.Body).Decode(&response); err != nil {
+		return err
+	}
+
+	if !response.Ok {
+		return fmt.Errorf(functionResponseFailed, method, response.Error)
+	}
+	return nil
+}
+
+// bindFunctionInputs sets the fields of a struct tagged with `slacker:"name"`
+// from the inputs with that name, converting them as JSON would
+func bindFunctionInputs(inputs map[string]any, out any) error {
+	target := reflect.ValueOf(out)
+	if target.Kind() != reflect.Pointer || target.IsNil() || target.Elem().Kind() != reflect.Struct {
+		return errInvalidBindTarget
+	}
+
+	target = target.Elem()
+	for i := 0; i < target.NumField(); i++ {
+		field := target.Type().Field(i)
+		name := field.Tag.Get(bindTag)
+		if len(name) == 0 || name == bindSkipTag || !field.IsExported() {
+			continue
+		}
+
+		value, ok := inputs[name]
+		if !ok || value == nil {
+			continue
+		}
+
+		if err := bindFunctionInput(target.Field(i), value); err != nil {
+			return fmt.Errorf(invalidFunctionInput, name, err)
+		}
+	}
+	return nil
+}
+
+// bindFunctionInput sets the field from the input value. Timestamps, sent as
+// seconds since the epoch, are bound to time.Time fields.
+func bindFunctionInput(field reflect.Value, value any) error {
+	if seconds, ok := value.(float64); ok && field.Type() == timeType {
+		field.Set(reflect.ValueOf(time.Unix(int64(seconds), 0)))
+		return nil
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, field.Addr().Interface())
+}
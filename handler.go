@@ -12,8 +12,143 @@ type InteractionMiddlewareHandler func(InteractionHandler) InteractionHandler
 // InteractionHandler represents the interaction handler function
 type InteractionHandler func(*InteractionContext)
 
+// ReactionMiddlewareHandler represents the reaction middleware handler function
+type ReactionMiddlewareHandler func(ReactionHandler) ReactionHandler
+
+// ReactionHandler represents the reaction handler function
+type ReactionHandler func(*ReactionContext)
+
+// EventMiddlewareHandler represents the event middleware handler function
+type EventMiddlewareHandler func(EventHandler) EventHandler
+
+// EventHandler represents the event handler function
+type EventHandler func(*EventContext)
+
+// AppHomeMiddlewareHandler represents the App Home middleware handler function
+type AppHomeMiddlewareHandler func(AppHomeHandler) AppHomeHandler
+
+// AppHomeHandler represents the App Home handler function
+type AppHomeHandler func(*AppHomeContext)
+
+// ConversationMiddlewareHandler represents the conversation middleware handler function
+type ConversationMiddlewareHandler func(ConversationHandler) ConversationHandler
+
+// ConversationHandler represents the conversation step handler function
+type ConversationHandler func(*ConversationContext)
+
+// OptionsMiddlewareHandler represents the options middleware handler function
+type OptionsMiddlewareHandler func(OptionsHandler) OptionsHandler
+
+// OptionsHandler represents the options handler function
+type OptionsHandler func(*OptionsContext)
+
+// FunctionMiddlewareHandler represents the function middleware handler function
+type FunctionMiddlewareHandler func(FunctionHandler) FunctionHandler
+
+// FunctionHandler represents the function handler function
+type FunctionHandler func(*FunctionContext)
+
 // JobMiddlewareHandler represents the job middleware handler function
 type JobMiddlewareHandler func(JobHandler) JobHandler
 
 // JobHandler represents the job handler function
 type JobHandler func(*JobContext)
+
+// CommandHandlerE represents a command handler function returning an error
+type CommandHandlerE func(*CommandContext) error
+
+// InteractionHandlerE represents an interaction handler function returning an error
+type InteractionHandlerE func(*InteractionContext) error
+
+// ReactionHandlerE represents a reaction handler function returning an error
+type ReactionHandlerE func(*ReactionContext) error
+
+// EventHandlerE represents an event handler function returning an error
+type EventHandlerE func(*EventContext) error
+
+// AppHomeHandlerE represents an App Home handler function returning an error
+type AppHomeHandlerE func(*AppHomeContext) error
+
+// ConversationHandlerE represents a conversation step handler function returning an error
+type ConversationHandlerE func(*ConversationContext) error
+
+// OptionsHandlerE represents an options handler function returning an error
+type OptionsHandlerE func(*OptionsContext) error
+
+// FunctionHandlerE represents a function handler function returning an error
+type FunctionHandlerE func(*FunctionContext) error
+
+// JobHandlerE represents a job handler function returning an error
+type JobHandlerE func(*JobContext) error
+
+// CommandHandlerWithError adapts a handler returning an error into a CommandHandler.
+// Returned errors are reported to the OnError hook.
+func CommandHandlerWithError(handler CommandHandlerE) CommandHandler {
+	return func(ctx *CommandContext) {
+		ctx.err = handler(ctx)
+	}
+}
+
+// InteractionHandlerWithError adapts a handler returning an error into an InteractionHandler.
+// Returned errors are reported to the OnError hook.
+func InteractionHandlerWithError(handler InteractionHandlerE) InteractionHandler {
+	return func(ctx *InteractionContext) {
+		ctx.err = handler(ctx)
+	}
+}
+
+// ReactionHandlerWithError adapts a handler returning an error into a ReactionHandler.
+// Returned errors are reported to the OnError hook.
+func ReactionHandlerWithError(handler ReactionHandlerE) ReactionHandler {
+	return func(ctx *ReactionContext) {
+		ctx.err = handler(ctx)
+	}
+}
+
+// EventHandlerWithError adapts a handler returning an error into an EventHandler.
+// Returned errors are reported to the OnError hook.
+func EventHandlerWithError(handler EventHandlerE) EventHandler {
+	return func(ctx *EventContext) {
+		ctx.err = handler(ctx)
+	}
+}
+
+// AppHomeHandlerWithError adapts a handler returning an error into an AppHomeHandler.
+// Returned errors are reported to the OnError hook, and nothing is published.
+func AppHomeHandlerWithError(handler AppHomeHandlerE) AppHomeHandler {
+	return func(ctx *AppHomeContext) {
+		ctx.err = handler(ctx)
+	}
+}
+
+// ConversationHandlerWithError adapts a handler returning an error into a ConversationHandler.
+// Returned errors are reported to the OnError hook.
+func ConversationHandlerWithError(handler ConversationHandlerE) ConversationHandler {
+	return func(ctx *ConversationContext) {
+		ctx.err = handler(ctx)
+	}
+}
+
+// OptionsHandlerWithError adapts a handler returning an error into an OptionsHandler.
+// Returned errors are reported to the OnError hook, and no options are suggested.
+func OptionsHandlerWithError(handler OptionsHandlerE) OptionsHandler {
+	return func(ctx *OptionsContext) {
+		ctx.err = handler(ctx)
+	}
+}
+
+// FunctionHandlerWithError adapts a handler returning an error into a FunctionHandler.
+// Returned errors are reported to the OnError hook, and fail the step.
+func FunctionHandlerWithError(handler FunctionHandlerE) FunctionHandler {
+	return func(ctx *FunctionContext) {
+		ctx.err = handler(ctx)
+	}
+}
+
+// JobHandlerWithError adapts a handler returning an error into a JobHandler.
+// Returned errors are reported to the OnError hook, and fail the attempt.
+func JobHandlerWithError(handler JobHandlerE) JobHandler {
+	return func(ctx *JobContext) {
+		ctx.err = handler(ctx)
+	}
+}
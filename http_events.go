@@ -0,0 +1,252 @@
+package slacker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/slack-go/slack"
+	"github.com/slack-go/slack/slackevents"
+	"github.com/slack-go/slack/socketmode"
+)
+
+const (
+	eventsPath           = "/slack/events"
+	commandsPath         = "/slack/commands"
+	interactionsPath     = "/slack/interactions"
+	payloadFormKey       = "payload"
+	shutdownGracePeriod  = 5 * time.Second
+	readHeaderTimeout    = 10 * time.Second
+	maxRequestSize       = 1 << 20
+	missingSigningSecret = "missing signing secret"
+)
+
+// listenHTTP serves the Events API request URLs until the context is done
+func (s *Slacker) listenHTTP(ctx context.Context) error {
+	if len(s.signingSecret) == 0 {
+		return errors.New(missingSigningSecret)
+	}
+
+	server := &http.Server{
+		Addr:              s.httpAddress,
+		Handler:           s.newHTTPHandler(),
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+
+	go func() {
+		<-ctx.Done()
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownGracePeriod)
+		defer cancel()
+
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			s.logger.Error("failed to shutdown http server", logKeyError, err)
+		}
+	}()
+
+	s.logger.Info("listening for Slack requests", "address", s.httpAddress)
+
+	err := server.ListenAndServe()
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
+
+// newHTTPHandler creates the handler serving the Events API request URLs
+func (s *Slacker) newHTTPHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(eventsPath, s.verifyRequest(func(w http.ResponseWriter, body []byte) {
+		s.handleHTTPEvent(w, body)
+	}))
+	mux.HandleFunc(commandsPath, s.verifyRequest(func(w http.ResponseWriter, body []byte) {
+		s.handleHTTPCommand(w, body)
+	}))
+	mux.HandleFunc(interactionsPath, s.verifyRequest(func(w http.ResponseWriter, body []byte) {
+		s.handleHTTPInteraction(w, body)
+	}))
+	return mux
+}
+
+// verifyRequest ensures the request was signed by Slack before passing its body along
+func (s *Slacker) verifyRequest(next func(http.ResponseWriter, []byte)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		verifier, err := slack.NewSecretsVerifier(r.Header, s.signingSecret)
+		if err != nil {
+			s.logger.Debug("unable to verify request", logKeyError, err)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		// Slack payloads are far smaller, bound what is read before the signature is checked
+		body, err := io.ReadAll(io.TeeReader(http.MaxBytesReader(w, r.Body, maxRequestSize), &verifier))
+		if err != nil && len(body) >= maxRequestSize {
+			s.logger.Debug("request too large", logKeyError, err)
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+			return
+		}
+
+		if err != nil {
+			s.logger.Error("unable to read request", logKeyError, err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if err := verifier.Ensure(); err != nil {
+			s.logger.Debug("unable to verify request", logKeyError, err)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		next(w, body)
+	}
+}
+
+func (s *Slacker) handleHTTPEvent(w http.ResponseWriter, body []byte) {
+	event, err := slackevents.ParseEvent(json.RawMessage(body), slackevents.OptionNoVerifyToken())
+	if err != nil {
+		// slack-go fails to parse the events it does not know about, such as function events
+		functionEvent, ok := parseFunctionEvent(body)
+		if !ok {
+			// Acknowledge events we are unable to parse so that Slack does not retry them
+			s.logger.Debug("unable to parse event", logKeyError, err)
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		event = functionEvent
+	}
+
+	if event.Type == slackevents.URLVerification {
+		verification, ok := event.Data.(*slackevents.EventsAPIURLVerificationEvent)
+		if !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte(verification.Challenge)); err != nil {
+			s.logger.Debug("unable to write challenge", logKeyError, err)
+		}
+		return
+	}
+
+	// Acknowledge receiving the request
+	w.WriteHeader(http.StatusOK)
+
+	s.appIDOnce.Do(func() {
+		s.appID = event.APIAppID
+	})
+
+	socketEvent := socketmode.Event{
+		Type: socketmode.EventTypeEventsAPI,
+		Data: event,
+		Request: &socketmode.Request{
+			Type:    socketmode.RequestTypeEventsAPI,
+			Payload: json.RawMessage(body),
+		},
+	}
+	s.dispatchEventsAPIEvent(socketEvent, event)
+}
+
+func (s *Slacker) handleHTTPCommand(w http.ResponseWriter, body []byte) {
+	request, err := newFormRequest(body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	event, err := slack.SlashCommandParse(request)
+	if err != nil {
+		s.logger.Debug("unable to parse slash command", logKeyError, err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	// Wait for the handler to acknowledge the request, possibly with a payload
+	s.writeAck(w, s.awaitAck(func(acknowledger *acknowledger) {
+		s.dispatchSlashCommand(event, acknowledger)
+	}))
+}
+
+func (s *Slacker) handleHTTPInteraction(w http.ResponseWriter, body []byte) {
+	request, err := newFormRequest(body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if err := request.ParseForm(); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	var callback slack.InteractionCallback
+	if err := json.Unmarshal([]byte(request.PostForm.Get(payloadFormKey)), &callback); err != nil {
+		s.logger.Debug("unable to parse interaction", logKeyError, err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if !requiresAckPayload(&callback) {
+		// Acknowledge receiving the request
+		w.WriteHeader(http.StatusOK)
+
+		s.dispatchInteraction(callback, nil)
+		return
+	}
+
+	// Wait for the handler to acknowledge the request, possibly with a payload
+	s.writeAck(w, s.awaitAck(func(acknowledger *acknowledger) {
+		s.dispatchInteraction(callback, acknowledger)
+	}))
+}
+
+// awaitAck dispatches a request with an acknowledger, returning the payload it is acknowledged with
+func (s *Slacker) awaitAck(dispatch func(acknowledger *acknowledger)) any {
+	payloads := make(chan any, 1)
+	dispatch(newAcknowledger(ackTimeout, func(payload any) {
+		payloads <- payload
+	}))
+	return <-payloads
+}
+
+// writeAck acknowledges the request with the payload encoded as JSON, if any
+func (s *Slacker) writeAck(w http.ResponseWriter, payload any) {
+	if payload == nil {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		s.logger.Error("unable to encode acknowledgement", logKeyError, err)
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(body); err != nil {
+		s.logger.Debug("unable to write acknowledgement", logKeyError, err)
+	}
+}
+
+// newFormRequest rebuilds a form request from an already consumed body
+func newFormRequest(body []byte) (*http.Request, error) {
+	request, err := http.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return request, nil
+}
@@ -0,0 +1,153 @@
+package slacker
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+const testSigningSecret = "8f742231b10e8888abcd99yyyzzz85a5"
+
+// newTestHTTPBot creates a bot receiving requests over HTTP, calling a Slack
+// API that accepts everything
+func newTestHTTPBot(t *testing.T) *Slacker {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	t.Cleanup(api.Close)
+
+	return NewClient("xoxb-test", "xapp-test",
+		WithTransportMode(TransportModeHTTP),
+		WithSigningSecret(testSigningSecret),
+		WithAPIURL(api.URL+"/"),
+	)
+}
+
+// newSignedRequest creates a request signed with the secret at the time
+func newSignedRequest(path string, body string, secret string, timestamp time.Time) *http.Request {
+	ts := strconv.FormatInt(timestamp.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "v0:%s:%s", ts, body)
+
+	request := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	request.Header.Set("X-Slack-Request-Timestamp", ts)
+	request.Header.Set("X-Slack-Signature", "v0="+hex.EncodeToString(mac.Sum(nil)))
+	return request
+}
+
+// serve handles the request with the bot's HTTP handler
+func serve(bot *Slacker, request *http.Request) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	bot.newHTTPHandler().ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestHTTPRequestVerification(t *testing.T) {
+	body := `{"type":"url_verification","challenge":"3eZbrw1aBm2rZgRNFdxV2595E9CY3gmdALWMmHkvFXO7tYXAYM8P"}`
+
+	tests := []struct {
+		name      string
+		secret    string
+		timestamp time.Time
+		status    int
+	}{
+		{name: "valid signature", secret: testSigningSecret, timestamp: time.Now(), status: http.StatusOK},
+		{name: "bad signature", secret: "another secret", timestamp: time.Now(), status: http.StatusUnauthorized},
+		{name: "stale timestamp", secret: testSigningSecret, timestamp: time.Now().Add(-10 * time.Minute), status: http.StatusUnauthorized},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bot := newTestHTTPBot(t)
+
+			response := serve(bot, newSignedRequest(eventsPath, body, test.secret, test.timestamp))
+			if response.Code != test.status {
+				t.Errorf("expected status %d, got %d", test.status, response.Code)
+			}
+		})
+	}
+}
+
+func TestHTTPURLVerification(t *testing.T) {
+	bot := newTestHTTPBot(t)
+
+	body := `{"type":"url_verification","challenge":"3eZbrw1aBm2rZgRNFdxV2595E9CY3gmdALWMmHkvFXO7tYXAYM8P"}`
+	response := serve(bot, newSignedRequest(eventsPath, body, testSigningSecret, time.Now()))
+
+	if response.Code != http.StatusOK || response.Body.String() != "3eZbrw1aBm2rZgRNFdxV2595E9CY3gmdALWMmHkvFXO7tYXAYM8P" {
+		t.Errorf("expected the challenge to be echoed, got %d %q", response.Code, response.Body.String())
+	}
+}
+
+func TestHTTPRequestTooLarge(t *testing.T) {
+	bot := newTestHTTPBot(t)
+
+	body := `{"type":"event_callback","padding":"` + strings.Repeat("x", maxRequestSize) + `"}`
+	response := serve(bot, newSignedRequest(eventsPath, body, testSigningSecret, time.Now()))
+
+	if response.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("expected status %d, got %d", http.StatusRequestEntityTooLarge, response.Code)
+	}
+}
+
+func TestHTTPSlashCommandAck(t *testing.T) {
+	bot := newTestHTTPBot(t)
+	bot.AddCommand(&CommandDefinition{
+		Command: "deploy",
+		Handler: func(ctx *CommandContext) {
+			ctx.Ack(&slack.Msg{Text: "deploying"})
+		},
+	})
+
+	form := url.Values{
+		"command":    {"/deploy"},
+		"text":       {""},
+		"channel_id": {"C123"},
+		"user_id":    {"U123"},
+		"team_id":    {"T123"},
+	}
+	response := serve(bot, newSignedRequest(commandsPath, form.Encode(), testSigningSecret, time.Now()))
+
+	var payload slack.Msg
+	if err := json.Unmarshal(response.Body.Bytes(), &payload); err != nil {
+		t.Fatalf("expected a JSON acknowledgement, got %q", response.Body.String())
+	}
+	if response.Code != http.StatusOK || payload.Text != "deploying" {
+		t.Errorf("expected the acknowledgement payload, got %d %+v", response.Code, payload)
+	}
+}
+
+func TestHTTPViewSubmissionAck(t *testing.T) {
+	bot := newTestHTTPBot(t)
+	bot.AddInteraction(&InteractionDefinition{
+		Type:          slack.InteractionTypeViewSubmission,
+		InteractionID: "feedback",
+		Handler: func(ctx *InteractionContext) {
+			ctx.AckErrors(ViewErrors{"comment": "Too short"})
+		},
+	})
+
+	callback := `{"type":"view_submission","user":{"id":"U123"},"view":{"callback_id":"feedback"}}`
+	form := url.Values{payloadFormKey: {callback}}
+	response := serve(bot, newSignedRequest(interactionsPath, form.Encode(), testSigningSecret, time.Now()))
+
+	var payload slack.ViewSubmissionResponse
+	if err := json.Unmarshal(response.Body.Bytes(), &payload); err != nil {
+		t.Fatalf("expected a JSON acknowledgement, got %q", response.Body.String())
+	}
+	if payload.ResponseAction != slack.RAErrors || payload.Errors["comment"] != "Too short" {
+		t.Errorf("expected the view errors to be acknowledged, got %+v", payload)
+	}
+}
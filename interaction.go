@@ -1,28 +1,197 @@
 package slacker
 
-import "github.com/slack-go/slack"
+import (
+	"regexp"
+	"strings"
+
+	"github.com/slack-go/slack"
+)
+
+// MatchMode determines how interaction and action IDs are matched
+type MatchMode int
+
+const (
+	// MatchExact matches IDs equal to the definition's
+	MatchExact MatchMode = iota
+
+	// MatchPrefix matches IDs starting with the definition's, capturing the rest
+	// of the ID. For instance, `approve:` matches `approve:42` and captures `42`.
+	MatchPrefix
+
+	// MatchRegex matches IDs against the definition's regular expression, capturing
+	// its groups. For instance, `^approve:(?P<request>\d+)$` matches `approve:42`.
+	MatchRegex
+)
 
 // InteractionDefinition structure contains definition of the bot interaction
 type InteractionDefinition struct {
+	// InteractionID matches the block ID of block actions, or the callback ID of
+	// views, shortcuts and message actions
 	InteractionID string
-	Middlewares   []InteractionMiddlewareHandler
-	Handler       InteractionHandler
-	Type          slack.InteractionType
+
+	// ActionID matches the action ID of block actions. When both InteractionID
+	// and ActionID are set, both the block ID and the action ID have to match.
+	ActionID string
+
+	// MatchMode determines how InteractionID and ActionID are matched, exactly by default
+	MatchMode MatchMode
+
+	Middlewares []InteractionMiddlewareHandler
+	Handler     InteractionHandler
+	Type        slack.InteractionType
+
+	// Roles restricts the interaction to users holding at least one of the roles
+	Roles []string
 }
 
-// newInteraction creates a new bot interaction object
-func newInteraction(definition *InteractionDefinition) *Interaction {
-	return &Interaction{
-		definition: definition,
+// name identifies the interaction in logs, metrics and stores
+func (d *InteractionDefinition) name() string {
+	if len(d.InteractionID) > 0 {
+		return d.InteractionID
 	}
+	return d.ActionID
+}
+
+// newInteraction creates a new bot interaction object. It fails if the IDs
+// are not valid regular expressions with MatchRegex.
+func newInteraction(definition *InteractionDefinition) (*Interaction, error) {
+	interactionMatcher, err := newIDMatcher(definition.MatchMode, definition.InteractionID)
+	if err != nil {
+		return nil, err
+	}
+
+	actionMatcher, err := newIDMatcher(definition.MatchMode, definition.ActionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Interaction{
+		definition:         definition,
+		interactionMatcher: interactionMatcher,
+		actionMatcher:      actionMatcher,
+	}, nil
 }
 
 // Interaction structure contains the bot's interaction, description and handler
 type Interaction struct {
-	definition *InteractionDefinition
+	definition         *InteractionDefinition
+	interactionMatcher *idMatcher
+	actionMatcher      *idMatcher
 }
 
 // Definition returns the interaction definition
 func (c *Interaction) Definition() *InteractionDefinition {
 	return c.definition
 }
+
+// Match determines whether the interaction handles the callback, returning the
+// captures of the match. Block actions are matched with MatchAction instead.
+func (c *Interaction) Match(callback *slack.InteractionCallback) (*InteractionMatch, bool) {
+	var id string
+	switch callback.Type {
+	case slack.InteractionTypeViewClosed, slack.InteractionTypeViewSubmission:
+		id = callback.View.CallbackID
+	case slack.InteractionTypeShortcut, slack.InteractionTypeMessageAction:
+		id = callback.CallbackID
+	default:
+		return nil, false
+	}
+
+	match := newInteractionMatch(nil)
+	if !c.interactionMatcher.match(id, match) {
+		return nil, false
+	}
+	return match, true
+}
+
+// MatchAction determines whether the interaction handles the block action,
+// returning the captures of the match
+func (c *Interaction) MatchAction(action *slack.BlockAction) (*InteractionMatch, bool) {
+	match := newInteractionMatch(action)
+	if !c.interactionMatcher.match(action.BlockID, match) {
+		return nil, false
+	}
+
+	if !c.actionMatcher.match(action.ActionID, match) {
+		return nil, false
+	}
+	return match, true
+}
+
+// newInteractionMatch creates a new interaction match structure
+func newInteractionMatch(action *slack.BlockAction) *InteractionMatch {
+	return &InteractionMatch{
+		Action:        action,
+		Captures:      []string{},
+		NamedCaptures: make(map[string]string),
+	}
+}
+
+// InteractionMatch contains what matched an interaction
+type InteractionMatch struct {
+	// Action is the matched block action, nil for other interaction types
+	Action *slack.BlockAction
+
+	// Captures are the rest of the IDs with MatchPrefix, or the groups of the
+	// regular expressions with MatchRegex. Interaction ID captures come first.
+	Captures []string
+
+	// NamedCaptures are the named groups of the regular expressions with MatchRegex
+	NamedCaptures map[string]string
+}
+
+// newIDMatcher creates a new ID matcher, matching any ID if the pattern is empty
+func newIDMatcher(mode MatchMode, pattern string) (*idMatcher, error) {
+	matcher := &idMatcher{mode: mode, pattern: pattern}
+	if mode != MatchRegex || len(pattern) == 0 {
+		return matcher, nil
+	}
+
+	regex, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	matcher.regex = regex
+	return matcher, nil
+}
+
+// idMatcher matches interaction or action IDs
+type idMatcher struct {
+	mode    MatchMode
+	pattern string
+	regex   *regexp.Regexp
+}
+
+// match determines whether the ID matches, adding its captures to the match
+func (m *idMatcher) match(id string, match *InteractionMatch) bool {
+	if len(m.pattern) == 0 {
+		return true
+	}
+
+	switch m.mode {
+	case MatchPrefix:
+		if !strings.HasPrefix(id, m.pattern) {
+			return false
+		}
+
+		match.Captures = append(match.Captures, strings.TrimPrefix(id, m.pattern))
+		return true
+	case MatchRegex:
+		submatches := m.regex.FindStringSubmatch(id)
+		if submatches == nil {
+			return false
+		}
+
+		names := m.regex.SubexpNames()
+		for i := 1; i < len(submatches); i++ {
+			match.Captures = append(match.Captures, submatches[i])
+			if len(names[i]) > 0 {
+				match.NamedCaptures[names[i]] = submatches[i]
+			}
+		}
+		return true
+	default:
+		return id == m.pattern
+	}
+}
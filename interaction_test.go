@@ -0,0 +1,166 @@
+package slacker_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/shomali11/slacker/v2"
+	"github.com/shomali11/slacker/v2/slackertest"
+	"github.com/slack-go/slack"
+)
+
+func newBlockActionsCallback(actions ...*slack.BlockAction) *slack.InteractionCallback {
+	callback := &slack.InteractionCallback{Type: slack.InteractionTypeBlockActions}
+	callback.Channel.ID = "C123"
+	callback.User.ID = "U123"
+	callback.ActionCallback.BlockActions = actions
+	return callback
+}
+
+func TestInteractionMatching(t *testing.T) {
+	tests := []struct {
+		name          string
+		definition    slacker.InteractionDefinition
+		action        *slack.BlockAction
+		matched       bool
+		captures      []string
+		namedCaptures map[string]string
+	}{
+		{
+			name:       "exact action",
+			definition: slacker.InteractionDefinition{ActionID: "approve"},
+			action:     &slack.BlockAction{ActionID: "approve"},
+			matched:    true,
+			captures:   []string{},
+		},
+		{
+			name:       "exact action mismatch",
+			definition: slacker.InteractionDefinition{ActionID: "approve"},
+			action:     &slack.BlockAction{ActionID: "approve-42"},
+		},
+		{
+			name:       "block and action",
+			definition: slacker.InteractionDefinition{InteractionID: "request", ActionID: "approve"},
+			action:     &slack.BlockAction{BlockID: "request", ActionID: "approve"},
+			matched:    true,
+			captures:   []string{},
+		},
+		{
+			name:       "block mismatch",
+			definition: slacker.InteractionDefinition{InteractionID: "request", ActionID: "approve"},
+			action:     &slack.BlockAction{BlockID: "other", ActionID: "approve"},
+		},
+		{
+			name:       "prefix",
+			definition: slacker.InteractionDefinition{ActionID: "approve-", MatchMode: slacker.MatchPrefix},
+			action:     &slack.BlockAction{ActionID: "approve-42"},
+			matched:    true,
+			captures:   []string{"42"},
+		},
+		{
+			name:       "prefix on block and action",
+			definition: slacker.InteractionDefinition{InteractionID: "request-", ActionID: "vote-", MatchMode: slacker.MatchPrefix},
+			action:     &slack.BlockAction{BlockID: "request-7", ActionID: "vote-yes"},
+			matched:    true,
+			captures:   []string{"7", "yes"},
+		},
+		{
+			name:       "regex",
+			definition: slacker.InteractionDefinition{ActionID: `^(approve|deny)-(?P<id>\d+)$`, MatchMode: slacker.MatchRegex},
+			action:     &slack.BlockAction{ActionID: "deny-42"},
+			matched:    true,
+			captures:   []string{"deny", "42"},
+			namedCaptures: map[string]string{
+				"id": "42",
+			},
+		},
+		{
+			name:       "regex mismatch",
+			definition: slacker.InteractionDefinition{ActionID: `^approve-\d+$`, MatchMode: slacker.MatchRegex},
+			action:     &slack.BlockAction{ActionID: "approve-abc"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			harness := slackertest.NewHarness()
+			defer harness.Close()
+
+			var ctx *slacker.InteractionContext
+			definition := test.definition
+			definition.Type = slack.InteractionTypeBlockActions
+			definition.Handler = func(interactionCtx *slacker.InteractionContext) {
+				ctx = interactionCtx
+			}
+			harness.Bot().AddInteraction(&definition)
+
+			harness.SendInteraction(newBlockActionsCallback(test.action))
+
+			if matched := ctx != nil; matched != test.matched {
+				t.Fatalf("expected matched %t, got %t", test.matched, matched)
+			}
+			if !test.matched {
+				return
+			}
+
+			if !reflect.DeepEqual(ctx.Captures(), test.captures) {
+				t.Errorf("expected captures %q, got %q", test.captures, ctx.Captures())
+			}
+			for name, value := range test.namedCaptures {
+				if ctx.Capture(name) != value {
+					t.Errorf("expected capture %s to be %q, got %q", name, value, ctx.Capture(name))
+				}
+			}
+		})
+	}
+}
+
+func TestInteractionMatchingEachAction(t *testing.T) {
+	harness := slackertest.NewHarness()
+	defer harness.Close()
+
+	handled := []string{}
+	for _, actionID := range []string{"approve", "deny"} {
+		harness.Bot().AddInteraction(&slacker.InteractionDefinition{
+			Type:     slack.InteractionTypeBlockActions,
+			ActionID: actionID,
+			Handler: func(ctx *slacker.InteractionContext) {
+				handled = append(handled, ctx.Action().ActionID)
+			},
+		})
+	}
+
+	harness.SendInteraction(newBlockActionsCallback(
+		&slack.BlockAction{ActionID: "deny"},
+		&slack.BlockAction{ActionID: "unknown"},
+		&slack.BlockAction{ActionID: "approve"},
+	))
+
+	if !reflect.DeepEqual(handled, []string{"deny", "approve"}) {
+		t.Errorf("expected each known action to be handled in order, got %q", handled)
+	}
+}
+
+func TestInteractionMatchingViewCallbackID(t *testing.T) {
+	harness := slackertest.NewHarness()
+	defer harness.Close()
+
+	var captures []string
+	harness.Bot().AddInteraction(&slacker.InteractionDefinition{
+		Type:          slack.InteractionTypeViewSubmission,
+		InteractionID: "feedback-",
+		MatchMode:     slacker.MatchPrefix,
+		Handler: func(ctx *slacker.InteractionContext) {
+			captures = ctx.Captures()
+		},
+	})
+
+	callback := &slack.InteractionCallback{Type: slack.InteractionTypeViewSubmission}
+	callback.User.ID = "U123"
+	callback.View.CallbackID = "feedback-survey"
+	harness.SubmitInteraction(callback)
+
+	if !reflect.DeepEqual(captures, []string{"survey"}) {
+		t.Errorf("expected the rest of the callback ID to be captured, got %q", captures)
+	}
+}
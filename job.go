@@ -1,5 +1,25 @@
 package slacker
 
+import "time"
+
+const defaultJobRetryBackoff = time.Second
+
+// OverlapPolicy determines what happens when a job is due while its previous
+// run is still running
+type OverlapPolicy int
+
+const (
+	// OverlapAllow runs the job alongside its previous run
+	OverlapAllow OverlapPolicy = iota
+
+	// OverlapSkip skips the run
+	OverlapSkip
+
+	// OverlapDelay runs the job once its previous run completes. Runs due while
+	// one is already delayed are coalesced into it.
+	OverlapDelay
+)
+
 // JobDefinition structure contains definition of the job
 type JobDefinition struct {
 	CronExpression string
@@ -10,10 +30,33 @@ type JobDefinition struct {
 
 	// HideHelp will hide this job definition from appearing in the `help` results.
 	HideHelp bool
+
+	// Overlap determines what happens when the job is due while still running,
+	// runs overlap by default
+	Overlap OverlapPolicy
+
+	// Timeout cancels the context of each attempt once elapsed, none by default
+	Timeout time.Duration
+
+	// Retries is how many times a failed run is retried. Runs fail when the
+	// handler panics or reports an error, see JobHandlerWithError.
+	Retries int
+
+	// RetryBackoff is the delay before the first retry, doubled for each next
+	// one, 1 second by default
+	RetryBackoff time.Duration
+
+	// Jitter delays scheduled runs by a random duration up to Jitter, to spread
+	// out jobs scheduled at the same time. Triggered runs are not delayed.
+	Jitter time.Duration
 }
 
 // newJob creates a new job object
 func newJob(definition *JobDefinition) *Job {
+	if definition.RetryBackoff <= 0 {
+		definition.RetryBackoff = defaultJobRetryBackoff
+	}
+
 	return &Job{
 		definition: definition,
 	}
@@ -0,0 +1,478 @@
+package slacker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+const (
+	jobNotFound           = "job `%s` not found"
+	jobAlreadyExists      = "job `%s` already exists"
+	jobAlreadyPaused      = "job `%s` is already paused"
+	jobNotPaused          = "job `%s` is not paused"
+	missingCronExpression = "missing `CronExpression`"
+	missingJobName        = "missing job name"
+	generatedJobIDFormat  = "job-%d"
+	jobsCommandPrefix     = "jobs"
+	jobTimeFormat         = "2006-01-02 15:04:05 MST"
+	noJobsMessage         = "No jobs"
+	jobPausedMessage      = "paused"
+	jobNeverRunMessage    = "never"
+)
+
+// JobStatus describes the schedule of a job
+type JobStatus struct {
+	// Name identifies the job, generated for unnamed jobs
+	Name string
+
+	Description    string
+	CronExpression string
+
+	// Paused reports whether the job was paused, it then only runs when triggered
+	Paused bool
+
+	// Next is the time of the next scheduled run, zero if paused
+	Next time.Time
+
+	// Previous is the time of the last run, scheduled or triggered, zero if it never ran
+	Previous time.Time
+}
+
+// newJobManager creates a new job manager scheduling jobs with the cron
+// client and running them with run
+func newJobManager(cronClient *cron.Cron, logger StructuredLogger, run func(definition *JobDefinition)) *JobManager {
+	return &JobManager{
+		cronClient: cronClient,
+		logger:     logger,
+		run:        run,
+		random:     rand.New(rand.NewSource(time.Now().UnixNano())),
+		stopped:    make(chan struct{}),
+	}
+}
+
+// JobManager schedules the bot's jobs. Jobs can be added, removed, paused,
+// resumed and triggered at any time, before or while the bot listens.
+type JobManager struct {
+	cronClient *cron.Cron
+	logger     StructuredLogger
+	run        func(definition *JobDefinition)
+	mutex      sync.Mutex
+	random     *rand.Rand
+	jobs       []*scheduledJob
+	generated  int
+
+	// stopped is closed once the manager stops, canceling jittered runs
+	stopped chan struct{}
+}
+
+// scheduledJob contains a job and the state of its schedule
+type scheduledJob struct {
+	job      *Job
+	id       string
+	entryID  cron.EntryID
+	paused   bool
+	previous time.Time
+
+	// running is held by the runs of jobs not allowed to overlap
+	running sync.Mutex
+
+	// pending reports whether a delayed run already waits for the running one
+	pending bool
+}
+
+// Add schedules a new job, managed by name afterwards, so names must be unique.
+// Unnamed jobs are given a generated name, `job-1`, `job-2` and so on, listed
+// in their status.
+func (m *JobManager) Add(definition *JobDefinition) error {
+	if len(definition.CronExpression) == 0 {
+		return errors.New(missingCronExpression)
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	id := definition.Name
+	if len(id) == 0 {
+		id = m.generateID()
+	} else if m.find(id) != nil {
+		return fmt.Errorf(jobAlreadyExists, id)
+	}
+
+	scheduled := &scheduledJob{job: newJob(definition), id: id}
+	if err := m.schedule(scheduled); err != nil {
+		return err
+	}
+
+	m.jobs = append(m.jobs, scheduled)
+	return nil
+}
+
+// Remove unschedules a job, waiting for none of its runs
+func (m *JobManager) Remove(name string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	scheduled, err := m.lookup(name)
+	if err != nil {
+		return err
+	}
+
+	m.cronClient.Remove(scheduled.entryID)
+	scheduled.entryID = 0
+	for i := range m.jobs {
+		if m.jobs[i] == scheduled {
+			m.jobs = append(m.jobs[:i], m.jobs[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// Pause stops running a job on schedule until it is resumed
+func (m *JobManager) Pause(name string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	scheduled, err := m.lookup(name)
+	if err != nil {
+		return err
+	}
+
+	if scheduled.paused {
+		return fmt.Errorf(jobAlreadyPaused, name)
+	}
+
+	m.cronClient.Remove(scheduled.entryID)
+	scheduled.entryID = 0
+	scheduled.paused = true
+	return nil
+}
+
+// Resume runs a paused job on schedule again
+func (m *JobManager) Resume(name string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	scheduled, err := m.lookup(name)
+	if err != nil {
+		return err
+	}
+
+	if !scheduled.paused {
+		return fmt.Errorf(jobNotPaused, name)
+	}
+
+	if err := m.schedule(scheduled); err != nil {
+		return err
+	}
+
+	scheduled.paused = false
+	return nil
+}
+
+// Run triggers a job immediately, paused or not, without affecting its schedule
+func (m *JobManager) Run(name string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	scheduled, err := m.lookup(name)
+	if err != nil {
+		return err
+	}
+
+	go m.runJob(scheduled, false)
+	return nil
+}
+
+// RunAndWait triggers a job like Run, returning once the run, retries
+// included, completes
+func (m *JobManager) RunAndWait(name string) error {
+	m.mutex.Lock()
+	scheduled, err := m.lookup(name)
+	m.mutex.Unlock()
+
+	if err != nil {
+		return err
+	}
+
+	m.runJob(scheduled, false)
+	return nil
+}
+
+// Status returns the schedule of a job
+func (m *JobManager) Status(name string) (*JobStatus, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	scheduled, err := m.lookup(name)
+	if err != nil {
+		return nil, err
+	}
+	return m.status(scheduled), nil
+}
+
+// List returns the schedule of every job, in the order they were added
+func (m *JobManager) List() []*JobStatus {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	statuses := make([]*JobStatus, 0, len(m.jobs))
+	for _, scheduled := range m.jobs {
+		statuses = append(statuses, m.status(scheduled))
+	}
+	return statuses
+}
+
+// getJobs returns the jobs, in the order they were added
+func (m *JobManager) getJobs() []*Job {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	jobs := make([]*Job, 0, len(m.jobs))
+	for _, scheduled := range m.jobs {
+		jobs = append(jobs, scheduled.job)
+	}
+	return jobs
+}
+
+// start runs the jobs on schedule
+func (m *JobManager) start() {
+	m.mutex.Lock()
+	select {
+	case <-m.stopped:
+		// Restarted after stopping
+		m.stopped = make(chan struct{})
+	default:
+	}
+	m.mutex.Unlock()
+
+	m.cronClient.Start()
+}
+
+// stop stops running the jobs on schedule and cancels the runs waiting for
+// their jitter, returning a context done once the runs in progress complete
+func (m *JobManager) stop() context.Context {
+	m.mutex.Lock()
+	select {
+	case <-m.stopped:
+	default:
+		close(m.stopped)
+	}
+	m.mutex.Unlock()
+
+	return m.cronClient.Stop()
+}
+
+// schedule adds the job to the cron client
+func (m *JobManager) schedule(scheduled *scheduledJob) error {
+	entryID, err := m.cronClient.AddFunc(scheduled.job.Definition().CronExpression, func() {
+		m.runJob(scheduled, true)
+	})
+	if err != nil {
+		return err
+	}
+
+	scheduled.entryID = entryID
+	return nil
+}
+
+// runJob runs the job as its overlap policy allows, delaying scheduled runs
+// by the job's jitter
+func (m *JobManager) runJob(scheduled *scheduledJob, onSchedule bool) {
+	definition := scheduled.job.Definition()
+	if onSchedule && definition.Jitter > 0 {
+		m.mutex.Lock()
+		stopped := m.stopped
+		m.mutex.Unlock()
+
+		timer := time.NewTimer(m.jitter(definition.Jitter))
+		select {
+		case <-timer.C:
+		case <-stopped:
+			timer.Stop()
+			return
+		}
+
+		m.mutex.Lock()
+		unscheduled := scheduled.entryID == 0
+		m.mutex.Unlock()
+
+		// The job may have been paused or removed in the meantime
+		if unscheduled {
+			return
+		}
+	}
+
+	switch definition.Overlap {
+	case OverlapSkip:
+		if !scheduled.running.TryLock() {
+			m.logger.Info("skipped job run, previous run still running", logKeyJob, scheduled.id)
+			return
+		}
+		defer scheduled.running.Unlock()
+	case OverlapDelay:
+		if !scheduled.running.TryLock() {
+			// At most one run waits, the runs due meanwhile are coalesced into it
+			m.mutex.Lock()
+			coalesced := scheduled.pending
+			scheduled.pending = true
+			m.mutex.Unlock()
+
+			if coalesced {
+				m.logger.Info("coalesced job run, a delayed run is already pending", logKeyJob, scheduled.id)
+				return
+			}
+
+			scheduled.running.Lock()
+
+			m.mutex.Lock()
+			scheduled.pending = false
+			m.mutex.Unlock()
+		}
+		defer scheduled.running.Unlock()
+	}
+
+	m.mutex.Lock()
+	scheduled.previous = time.Now()
+	m.mutex.Unlock()
+
+	m.run(definition)
+}
+
+// jitter returns a random duration up to max
+func (m *JobManager) jitter(max time.Duration) time.Duration {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return time.Duration(m.random.Int63n(int64(max)))
+}
+
+// lookup returns the job with the name, failing for empty or unknown names
+func (m *JobManager) lookup(name string) (*scheduledJob, error) {
+	if len(name) == 0 {
+		return nil, errors.New(missingJobName)
+	}
+
+	scheduled := m.find(name)
+	if scheduled == nil {
+		return nil, fmt.Errorf(jobNotFound, name)
+	}
+	return scheduled, nil
+}
+
+// find returns the job with the name, or nil
+func (m *JobManager) find(name string) *scheduledJob {
+	for _, scheduled := range m.jobs {
+		if scheduled.id == name {
+			return scheduled
+		}
+	}
+	return nil
+}
+
+// generateID returns an unused name for an unnamed job
+func (m *JobManager) generateID() string {
+	for {
+		m.generated++
+		id := fmt.Sprintf(generatedJobIDFormat, m.generated)
+		if m.find(id) == nil {
+			return id
+		}
+	}
+}
+
+// status returns the schedule of the job
+func (m *JobManager) status(scheduled *scheduledJob) *JobStatus {
+	definition := scheduled.job.Definition()
+	status := &JobStatus{
+		Name:           scheduled.id,
+		Description:    definition.Description,
+		CronExpression: definition.CronExpression,
+		Paused:         scheduled.paused,
+		Previous:       scheduled.previous,
+	}
+
+	if scheduled.paused {
+		return status
+	}
+
+	entry := m.cronClient.Entry(scheduled.entryID)
+	status.Next = entry.Next
+	if status.Next.IsZero() && entry.Schedule != nil {
+		// The cron client computes the next run once started
+		status.Next = entry.Schedule.Next(time.Now())
+	}
+	return status
+}
+
+// AddJobCommands adds the `jobs` command group managing the bot's jobs:
+// `jobs list`, `jobs run <name>`, `jobs pause <name>` and `jobs resume <name>`.
+// The commands are restricted to users holding at least one of the roles, if any.
+func (s *Slacker) AddJobCommands(roles ...string) *CommandGroup {
+	group := s.AddCommandGroup(jobsCommandPrefix)
+	group.AddRoles(roles...)
+
+	group.AddCommand(&CommandDefinition{
+		Command:     "list",
+		Description: "List the jobs and their schedule",
+		Handler: func(ctx *CommandContext) {
+			ctx.Response().Reply(formatJobStatuses(s.jobManager.List()))
+		},
+	})
+
+	manage := func(command string, description string, reply string, action func(name string) error) {
+		group.AddCommand(&CommandDefinition{
+			Command:     command + " <name>",
+			Description: description,
+			Handler: func(ctx *CommandContext) {
+				name := ctx.Request().Param("name")
+				if err := action(name); err != nil {
+					ctx.Response().ReplyError(err)
+					return
+				}
+				ctx.Response().Reply(fmt.Sprintf(reply, name))
+			},
+		})
+	}
+
+	manage("run", "Run a job now", "Job `%s` triggered", s.jobManager.Run)
+	manage("pause", "Pause a job", "Job `%s` paused", s.jobManager.Pause)
+	manage("resume", "Resume a paused job", "Job `%s` resumed", s.jobManager.Resume)
+	return group
+}
+
+// formatJobStatuses formats the statuses as a message, one job per line
+func formatJobStatuses(statuses []*JobStatus) string {
+	if len(statuses) == 0 {
+		return noJobsMessage
+	}
+
+	lines := make([]string, 0, len(statuses))
+	for _, status := range statuses {
+		line := fmt.Sprintf(codeMessageFormat, status.Name) + space + fmt.Sprintf(codeMessageFormat, status.CronExpression)
+
+		next := jobPausedMessage
+		if !status.Paused {
+			next = formatJobTime(status.Next)
+		}
+
+		line += space + dash + space + fmt.Sprintf(italicMessageFormat, "next: "+next+", previous: "+formatJobTime(status.Previous))
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, newLine)
+}
+
+// formatJobTime formats the time of a run
+func formatJobTime(runTime time.Time) string {
+	if runTime.IsZero() {
+		return jobNeverRunMessage
+	}
+	return runTime.Format(jobTimeFormat)
+}
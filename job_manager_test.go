@@ -0,0 +1,276 @@
+package slacker_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shomali11/slacker/v2"
+	"github.com/shomali11/slacker/v2/slackertest"
+)
+
+func TestJobManagerNames(t *testing.T) {
+	harness := slackertest.NewHarness()
+	defer harness.Close()
+
+	manager := harness.Bot().JobManager()
+	noop := func(*slacker.JobContext) {}
+
+	if err := manager.Add(&slacker.JobDefinition{CronExpression: "0 * * * *", Handler: noop}); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if err := manager.Add(&slacker.JobDefinition{Name: "report", CronExpression: "0 * * * *", Handler: noop}); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if err := manager.Add(&slacker.JobDefinition{Name: "report", CronExpression: "0 * * * *", Handler: noop}); err == nil {
+		t.Error("expected an error adding a job with a used name")
+	}
+
+	statuses := manager.List()
+	if len(statuses) != 2 || statuses[0].Name != "job-1" || statuses[1].Name != "report" {
+		t.Fatalf("expected the unnamed job to be given a name, got %+v", statuses)
+	}
+
+	if err := manager.Pause("job-1"); err != nil {
+		t.Errorf("expected the unnamed job to be managed by its generated name, got %v", err)
+	}
+
+	for name, action := range map[string]func(string) error{
+		"Remove": manager.Remove,
+		"Pause":  manager.Pause,
+		"Resume": manager.Resume,
+		"Run":    manager.Run,
+	} {
+		if err := action(""); err == nil {
+			t.Errorf("expected %s to reject an empty name", name)
+		}
+	}
+	if _, err := manager.Status(""); err == nil {
+		t.Error("expected Status to reject an empty name")
+	}
+}
+
+func TestJobManagerPauseResume(t *testing.T) {
+	harness := slackertest.NewHarness()
+	defer harness.Close()
+
+	manager := harness.Bot().JobManager()
+	ran := make(chan struct{}, 1)
+	err := manager.Add(&slacker.JobDefinition{
+		Name:           "report",
+		CronExpression: "0 * * * *",
+		Handler: func(*slacker.JobContext) {
+			ran <- struct{}{}
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if err := manager.Pause("report"); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if err := manager.Pause("report"); err == nil {
+		t.Error("expected an error pausing a paused job")
+	}
+
+	status, _ := manager.Status("report")
+	if !status.Paused || !status.Next.IsZero() {
+		t.Errorf("expected a paused job without next run, got %+v", status)
+	}
+
+	// Paused jobs still run when triggered
+	if err := manager.Run("report"); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	select {
+	case <-ran:
+	case <-time.After(time.Second):
+		t.Fatal("expected the triggered job to run")
+	}
+
+	if err := manager.Resume("report"); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	status, _ = manager.Status("report")
+	if status.Paused || status.Next.IsZero() || status.Previous.IsZero() {
+		t.Errorf("expected a resumed job with next and previous runs, got %+v", status)
+	}
+}
+
+func TestJobRetries(t *testing.T) {
+	harness := slackertest.NewHarness()
+	defer harness.Close()
+
+	attempts := make(chan int, 3)
+	err := harness.Bot().JobManager().Add(&slacker.JobDefinition{
+		Name:           "flaky",
+		CronExpression: "0 * * * *",
+		Retries:        2,
+		RetryBackoff:   10 * time.Millisecond,
+		Handler: slacker.JobHandlerWithError(func(ctx *slacker.JobContext) error {
+			attempts <- ctx.Attempt()
+			if ctx.Attempt() < 3 {
+				return errors.New("flaky")
+			}
+			return nil
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	start := time.Now()
+	harness.Bot().JobManager().Run("flaky")
+
+	for expected := 1; expected <= 3; expected++ {
+		select {
+		case attempt := <-attempts:
+			if attempt != expected {
+				t.Fatalf("expected attempt %d, got %d", expected, attempt)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("expected attempt %d to run", expected)
+		}
+	}
+
+	// The backoff doubles, 10ms then 20ms
+	if elapsed := time.Since(start); elapsed < 30*time.Millisecond {
+		t.Errorf("expected the retries to back off, took %s", elapsed)
+	}
+}
+
+func TestJobRetriesExhausted(t *testing.T) {
+	harness := slackertest.NewHarness()
+	defer harness.Close()
+
+	attempts := make(chan int, 3)
+	harness.Bot().JobManager().Add(&slacker.JobDefinition{
+		Name:           "broken",
+		CronExpression: "0 * * * *",
+		Retries:        1,
+		RetryBackoff:   time.Millisecond,
+		Handler: slacker.JobHandlerWithError(func(ctx *slacker.JobContext) error {
+			attempts <- ctx.Attempt()
+			return errors.New("broken")
+		}),
+	})
+
+	harness.RunJob("broken")
+
+	if count := len(attempts); count != 2 {
+		t.Errorf("expected the first attempt and a retry, got %d attempts", count)
+	}
+}
+
+func TestJobTimeout(t *testing.T) {
+	harness := slackertest.NewHarness()
+	defer harness.Close()
+
+	result := make(chan error, 1)
+	harness.Bot().JobManager().Add(&slacker.JobDefinition{
+		Name:           "slow",
+		CronExpression: "0 * * * *",
+		Timeout:        10 * time.Millisecond,
+		Handler: func(ctx *slacker.JobContext) {
+			<-ctx.Context().Done()
+			result <- ctx.Context().Err()
+		},
+	})
+
+	harness.Bot().JobManager().Run("slow")
+
+	select {
+	case err := <-result:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected the context to time out, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the job context to be canceled by the timeout")
+	}
+}
+
+func TestJobOverlapDelayCoalescesRuns(t *testing.T) {
+	harness := slackertest.NewHarness()
+	defer harness.Close()
+
+	started := make(chan struct{}, 10)
+	release := make(chan struct{})
+	harness.Bot().JobManager().Add(&slacker.JobDefinition{
+		Name:           "long",
+		CronExpression: "0 * * * *",
+		Overlap:        slacker.OverlapDelay,
+		Handler: func(*slacker.JobContext) {
+			started <- struct{}{}
+			<-release
+		},
+	})
+
+	returned := make(chan struct{}, 4)
+	runAndWait := func() {
+		harness.RunJob("long")
+		returned <- struct{}{}
+	}
+
+	go runAndWait()
+	<-started
+
+	// One run is delayed, the others are coalesced into it and return at once
+	for i := 0; i < 3; i++ {
+		go runAndWait()
+	}
+	for i := 0; i < 2; i++ {
+		<-returned
+	}
+	close(release)
+
+	// The first and the delayed runs
+	for i := 0; i < 2; i++ {
+		<-returned
+	}
+
+	if count := len(started); count != 1 {
+		t.Errorf("expected a single delayed run, got %d", count)
+	}
+}
+
+func TestJobOverlapSkip(t *testing.T) {
+	harness := slackertest.NewHarness()
+	defer harness.Close()
+
+	started := make(chan struct{}, 10)
+	release := make(chan struct{})
+	harness.Bot().JobManager().Add(&slacker.JobDefinition{
+		Name:           "long",
+		CronExpression: "0 * * * *",
+		Overlap:        slacker.OverlapSkip,
+		Handler: func(*slacker.JobContext) {
+			started <- struct{}{}
+			<-release
+		},
+	})
+
+	done := make(chan struct{})
+	go func() {
+		harness.RunJob("long")
+		close(done)
+	}()
+	<-started
+
+	// Skipped while the previous run is still running
+	harness.RunJob("long")
+	if count := len(started); count != 0 {
+		t.Errorf("expected the overlapping run to be skipped, got %d runs", count)
+	}
+
+	close(release)
+	<-done
+
+	// Runs again once the previous run completed
+	harness.RunJob("long")
+	if count := len(started); count != 1 {
+		t.Errorf("expected the run after completion to start, got %d runs", count)
+	}
+}
@@ -1,10 +1,34 @@
 package slacker
 
 import (
+	"fmt"
 	"log"
 	"os"
+	"strconv"
+	"strings"
 )
 
+const (
+	badKey                  = "!BADKEY"
+	warnPrefix              = "WARN"
+	logKeyChannelID         = "channel_id"
+	logKeyUserID            = "user_id"
+	logKeyCommand           = "command"
+	logKeyInteraction       = "interaction_id"
+	logKeyInteractionType   = "interaction_type"
+	logKeyAction            = "action_id"
+	logKeyEvent             = "event_type"
+	logKeyReaction          = "reaction"
+	logKeyJob               = "job"
+	logKeyConversation      = "conversation"
+	logKeyStep              = "step"
+	logKeyFunction          = "function"
+	logKeyFunctionExecution = "function_execution_id"
+	logKeyAttempt           = "attempt"
+	logKeyError             = "error"
+)
+
+// Logger logs printf style messages
 type Logger interface {
 	Info(args ...interface{})
 	Infof(format string, args ...interface{})
@@ -53,3 +77,152 @@ func (l *builtinLogger) Error(args ...interface{}) {
 func (l *builtinLogger) Errorf(format string, args ...interface{}) {
 	l.logger.Printf(format, args...)
 }
+
+// StructuredLogger logs leveled messages along with key/value pairs
+type StructuredLogger interface {
+	Debug(msg string, keysAndValues ...any)
+	Info(msg string, keysAndValues ...any)
+	Warn(msg string, keysAndValues ...any)
+	Error(msg string, keysAndValues ...any)
+
+	// With returns a logger adding the key/value pairs to every message
+	With(keysAndValues ...any) StructuredLogger
+}
+
+// NewPrintfLogger adapts a Logger into a StructuredLogger. Key/value pairs are
+// appended to messages as `key=value` and warnings are logged as info,
+// prefixed with `WARN`.
+func NewPrintfLogger(logger Logger) StructuredLogger {
+	return &printfLogger{logger: logger}
+}
+
+type printfLogger struct {
+	logger Logger
+	fields []any
+}
+
+func (l *printfLogger) Debug(msg string, keysAndValues ...any) {
+	l.logger.Debugf("%s\n", l.format(msg, keysAndValues))
+}
+
+func (l *printfLogger) Info(msg string, keysAndValues ...any) {
+	l.logger.Infof("%s\n", l.format(msg, keysAndValues))
+}
+
+func (l *printfLogger) Warn(msg string, keysAndValues ...any) {
+	l.logger.Infof("%s %s\n", warnPrefix, l.format(msg, keysAndValues))
+}
+
+func (l *printfLogger) Error(msg string, keysAndValues ...any) {
+	l.logger.Errorf("%s\n", l.format(msg, keysAndValues))
+}
+
+func (l *printfLogger) With(keysAndValues ...any) StructuredLogger {
+	return &printfLogger{logger: l.logger, fields: appendFields(l.fields, keysAndValues)}
+}
+
+func (l *printfLogger) format(msg string, keysAndValues []any) string {
+	fields := appendFields(l.fields, keysAndValues)
+
+	var builder strings.Builder
+	builder.WriteString(msg)
+	for i := 0; i < len(fields); i += 2 {
+		builder.WriteString(space)
+		builder.WriteString(formatField(fields[i]))
+		builder.WriteString("=")
+		builder.WriteString(formatField(fields[i+1]))
+	}
+	return builder.String()
+}
+
+// KeyValueLogger is implemented by loggers taking key/value pairs, such as zap's SugaredLogger
+type KeyValueLogger interface {
+	Debugw(msg string, keysAndValues ...interface{})
+	Infow(msg string, keysAndValues ...interface{})
+	Warnw(msg string, keysAndValues ...interface{})
+	Errorw(msg string, keysAndValues ...interface{})
+}
+
+// NewKeyValueLogger adapts a KeyValueLogger into a StructuredLogger
+func NewKeyValueLogger(logger KeyValueLogger) StructuredLogger {
+	return &keyValueLogger{logger: logger}
+}
+
+type keyValueLogger struct {
+	logger KeyValueLogger
+	fields []any
+}
+
+func (l *keyValueLogger) Debug(msg string, keysAndValues ...any) {
+	l.logger.Debugw(msg, appendFields(l.fields, keysAndValues)...)
+}
+
+func (l *keyValueLogger) Info(msg string, keysAndValues ...any) {
+	l.logger.Infow(msg, appendFields(l.fields, keysAndValues)...)
+}
+
+func (l *keyValueLogger) Warn(msg string, keysAndValues ...any) {
+	l.logger.Warnw(msg, appendFields(l.fields, keysAndValues)...)
+}
+
+func (l *keyValueLogger) Error(msg string, keysAndValues ...any) {
+	l.logger.Errorw(msg, appendFields(l.fields, keysAndValues)...)
+}
+
+func (l *keyValueLogger) With(keysAndValues ...any) StructuredLogger {
+	return &keyValueLogger{logger: l.logger, fields: appendFields(l.fields, keysAndValues)}
+}
+
+// newLoggerAdapter adapts a StructuredLogger into a Logger
+func newLoggerAdapter(logger StructuredLogger) Logger {
+	return &loggerAdapter{logger: logger}
+}
+
+type loggerAdapter struct {
+	logger StructuredLogger
+}
+
+func (l *loggerAdapter) Info(args ...interface{}) {
+	l.logger.Info(fmt.Sprint(args...))
+}
+
+func (l *loggerAdapter) Infof(format string, args ...interface{}) {
+	l.logger.Info(strings.TrimSuffix(fmt.Sprintf(format, args...), newLine))
+}
+
+func (l *loggerAdapter) Debug(args ...interface{}) {
+	l.logger.Debug(fmt.Sprint(args...))
+}
+
+func (l *loggerAdapter) Debugf(format string, args ...interface{}) {
+	l.logger.Debug(strings.TrimSuffix(fmt.Sprintf(format, args...), newLine))
+}
+
+func (l *loggerAdapter) Error(args ...interface{}) {
+	l.logger.Error(fmt.Sprint(args...))
+}
+
+func (l *loggerAdapter) Errorf(format string, args ...interface{}) {
+	l.logger.Error(strings.TrimSuffix(fmt.Sprintf(format, args...), newLine))
+}
+
+// appendFields returns a new slice with the key/value pairs appended, pairing
+// a dangling value with the `!BADKEY` key
+func appendFields(fields []any, keysAndValues []any) []any {
+	result := make([]any, 0, len(fields)+len(keysAndValues)+1)
+	result = append(result, fields...)
+	result = append(result, keysAndValues...)
+	if len(keysAndValues)%2 != 0 {
+		result = append(result[:len(result)-1], badKey, result[len(result)-1])
+	}
+	return result
+}
+
+// formatField formats a key or value, quoting it if needed
+func formatField(field any) string {
+	value := fmt.Sprint(field)
+	if len(value) == 0 || strings.ContainsAny(value, " =\"\n\t") {
+		return strconv.Quote(value)
+	}
+	return value
+}
@@ -0,0 +1,66 @@
+package slacker_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/shomali11/slacker/v2"
+	"github.com/shomali11/slacker/v2/slackertest"
+)
+
+// printfRecorder records the lines logged through a printf style logger
+type printfRecorder struct {
+	lines []string
+}
+
+func (r *printfRecorder) Info(args ...interface{}) {
+	r.lines = append(r.lines, fmt.Sprint(args...))
+}
+
+func (r *printfRecorder) Infof(format string, args ...interface{}) {
+	r.lines = append(r.lines, fmt.Sprintf(format, args...))
+}
+
+func (r *printfRecorder) Debug(args ...interface{}) {}
+
+func (r *printfRecorder) Debugf(format string, args ...interface{}) {}
+
+func (r *printfRecorder) Error(args ...interface{}) {
+	r.lines = append(r.lines, fmt.Sprint(args...))
+}
+
+func (r *printfRecorder) Errorf(format string, args ...interface{}) {
+	r.lines = append(r.lines, fmt.Sprintf(format, args...))
+}
+
+func TestPrintfLoggerLevels(t *testing.T) {
+	recorder := &printfRecorder{}
+	logger := slacker.NewPrintfLogger(recorder).With("job", "report")
+
+	logger.Info("started")
+	logger.Warn("slow", "seconds", 3)
+
+	expected := []string{"started job=report\n", "WARN slow job=report seconds=3\n"}
+	if fmt.Sprint(recorder.lines) != fmt.Sprint(expected) {
+		t.Errorf("expected warnings to be told apart from info, got %q", recorder.lines)
+	}
+}
+
+func TestContextLoggerAddsMetadata(t *testing.T) {
+	recorder := &printfRecorder{}
+	harness := slackertest.NewHarness(slacker.WithLogger(recorder))
+	defer harness.Close()
+
+	harness.Bot().AddCommand(&slacker.CommandDefinition{
+		Command: "ping",
+		Handler: func(ctx *slacker.CommandContext) {
+			ctx.Logger().Infof("pong")
+		},
+	})
+	harness.SendMessage("C123", "U123", "ping")
+
+	expected := "pong channel_id=C123 user_id=U123 command=ping\n"
+	if len(recorder.lines) == 0 || recorder.lines[len(recorder.lines)-1] != expected {
+		t.Errorf("expected the configured logger to log with the event metadata, got %q", recorder.lines)
+	}
+}
@@ -1,7 +1,10 @@
 package slacker
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"sync"
 
 	"github.com/slack-go/slack"
 	"github.com/slack-go/slack/slackevents"
@@ -16,13 +19,19 @@ type MessageEvent struct {
 	// Channel ID where the message was sent
 	ChannelID string
 
-	// Channel contains information about the channel
+	// Channel contains information about the channel.
+	//
+	// Deprecated: Channel is nil with WithLazyLookups until GetChannel is
+	// called, use GetChannel instead which works in both modes.
 	Channel *slack.Channel
 
 	// User ID of the sender
 	UserID string
 
-	// UserProfile contains all the information details of a given user
+	// UserProfile contains all the information details of a given user.
+	//
+	// Deprecated: UserProfile is nil with WithLazyLookups until GetUserProfile
+	// is called, use GetUserProfile instead which works in both modes.
 	UserProfile *slack.UserProfile
 
 	// Text is the unalterted text of the message, as returned by Slack
@@ -48,6 +57,31 @@ type MessageEvent struct {
 	// BotID of the bot that sent this message. If a bot did not send this
 	// message, this will be an empty string.
 	BotID string
+
+	loadChannel     func() *slack.Channel
+	loadUserProfile func() *slack.UserProfile
+	channelOnce     sync.Once
+	userProfileOnce sync.Once
+}
+
+// GetChannel returns information about the channel, looking it up first if needed
+func (e *MessageEvent) GetChannel() *slack.Channel {
+	e.channelOnce.Do(func() {
+		if e.Channel == nil && e.loadChannel != nil {
+			e.Channel = e.loadChannel()
+		}
+	})
+	return e.Channel
+}
+
+// GetUserProfile returns the profile of the sender, looking it up first if needed
+func (e *MessageEvent) GetUserProfile() *slack.UserProfile {
+	e.userProfileOnce.Do(func() {
+		if e.UserProfile == nil && e.loadUserProfile != nil {
+			e.UserProfile = e.loadUserProfile()
+		}
+	})
+	return e.UserProfile
 }
 
 // InThread indicates if a message event took place in a thread.
@@ -60,17 +94,32 @@ func (e *MessageEvent) IsBot() bool {
 	return e.BotID != ""
 }
 
-// newMessageEvent creates a new message event structure
-func newMessageEvent(logger Logger, slackClient *slack.Client, event any) *MessageEvent {
+// TriggerID returns the trigger ID to open views with, only set for slash commands
+func (e *MessageEvent) TriggerID() string {
+	if command, ok := e.Data.(*slack.SlashCommand); ok {
+		return command.TriggerID
+	}
+	return ""
+}
+
+// ResponseURL returns the URL to respond to, only set for slash commands
+func (e *MessageEvent) ResponseURL() string {
+	if command, ok := e.Data.(*slack.SlashCommand); ok {
+		return command.ResponseURL
+	}
+	return ""
+}
+
+// newMessageEvent creates a new message event structure. Channel and user
+// lookups go through the cache, and are deferred until accessed when lazy.
+func newMessageEvent(ctx context.Context, logger StructuredLogger, slackClient *slack.Client, cache Cache, lazyLookups bool, event any) *MessageEvent {
 	var messageEvent *MessageEvent
 
 	switch ev := event.(type) {
 	case *slackevents.MessageEvent:
 		messageEvent = &MessageEvent{
 			ChannelID:       ev.Channel,
-			Channel:         getChannel(logger, slackClient, ev.Channel),
 			UserID:          ev.User,
-			UserProfile:     getUserProfile(logger, slackClient, ev.User),
 			Text:            ev.Text,
 			Data:            event,
 			Type:            ev.Type,
@@ -81,9 +130,7 @@ func newMessageEvent(logger Logger, slackClient *slack.Client, event any) *Messa
 	case *slackevents.AppMentionEvent:
 		messageEvent = &MessageEvent{
 			ChannelID:       ev.Channel,
-			Channel:         getChannel(logger, slackClient, ev.Channel),
 			UserID:          ev.User,
-			UserProfile:     getUserProfile(logger, slackClient, ev.User),
 			Text:            ev.Text,
 			Data:            event,
 			Type:            ev.Type,
@@ -93,46 +140,105 @@ func newMessageEvent(logger Logger, slackClient *slack.Client, event any) *Messa
 		}
 	case *slack.SlashCommand:
 		messageEvent = &MessageEvent{
-			ChannelID:   ev.ChannelID,
-			Channel:     getChannel(logger, slackClient, ev.ChannelID),
-			UserID:      ev.UserID,
-			UserProfile: getUserProfile(logger, slackClient, ev.UserID),
-			Text:        fmt.Sprintf("%s %s", ev.Command[1:], ev.Text),
-			Data:        event,
-			Type:        socketmode.RequestTypeSlashCommands,
+			ChannelID: ev.ChannelID,
+			UserID:    ev.UserID,
+			Text:      fmt.Sprintf("%s %s", ev.Command[1:], ev.Text),
+			Data:      event,
+			Type:      socketmode.RequestTypeSlashCommands,
 		}
 	default:
 		return nil
 	}
 
+	messageEvent.loadChannel = func() *slack.Channel {
+		return getChannel(ctx, logger, slackClient, cache, messageEvent.ChannelID)
+	}
+	messageEvent.loadUserProfile = func() *slack.UserProfile {
+		return getUserProfile(ctx, logger, slackClient, cache, messageEvent.UserID)
+	}
+
+	if !lazyLookups {
+		messageEvent.GetChannel()
+		messageEvent.GetUserProfile()
+	}
 	return messageEvent
 }
 
-func getChannel(logger Logger, slackClient *slack.Client, channelID string) *slack.Channel {
+func getChannel(ctx context.Context, logger StructuredLogger, slackClient *slack.Client, cache Cache, channelID string) *slack.Channel {
 	if len(channelID) == 0 {
 		return nil
 	}
 
-	channel, err := slackClient.GetConversationInfo(&slack.GetConversationInfoInput{
+	ctx, span := StartSpan(ctx, spanLookupChannel, Attr(logKeyChannelID, channelID))
+	defer span.End()
+
+	channel := &slack.Channel{}
+	if getCached(cache, channelCacheKey+channelID, channel) {
+		span.SetAttributes(Attr(attributeCacheHit, true))
+		return channel
+	}
+
+	channel, err := slackClient.GetConversationInfoContext(ctx, &slack.GetConversationInfoInput{
 		ChannelID:         channelID,
 		IncludeLocale:     false,
 		IncludeNumMembers: false})
 	if err != nil {
-		logger.Errorf("unable to get channel info for %s: %v\n", channelID, err)
+		span.RecordError(err)
+		logger.Error("unable to get channel info", logKeyChannelID, channelID, logKeyError, err)
 		return nil
 	}
+
+	setCached(cache, channelCacheKey+channelID, channel)
 	return channel
 }
 
-func getUserProfile(logger Logger, slackClient *slack.Client, userID string) *slack.UserProfile {
+func getUserProfile(ctx context.Context, logger StructuredLogger, slackClient *slack.Client, cache Cache, userID string) *slack.UserProfile {
 	if len(userID) == 0 {
 		return nil
 	}
 
-	user, err := slackClient.GetUserInfo(userID)
+	ctx, span := StartSpan(ctx, spanLookupUser, Attr(logKeyUserID, userID))
+	defer span.End()
+
+	profile := &slack.UserProfile{}
+	if getCached(cache, userCacheKey+userID, profile) {
+		span.SetAttributes(Attr(attributeCacheHit, true))
+		return profile
+	}
+
+	user, err := slackClient.GetUserInfoContext(ctx, userID)
 	if err != nil {
-		logger.Errorf("unable to get user info for %s: %v\n", userID, err)
+		span.RecordError(err)
+		logger.Error("unable to get user info", logKeyUserID, userID, logKeyError, err)
 		return nil
 	}
+
+	setCached(cache, userCacheKey+userID, &user.Profile)
 	return &user.Profile
 }
+
+// getCached decodes the cached value of the key into out, returning false if missing
+func getCached(cache Cache, key string, out any) bool {
+	if cache == nil {
+		return false
+	}
+
+	value, ok := cache.Get(key)
+	if !ok {
+		return false
+	}
+	return json.Unmarshal(value, out) == nil
+}
+
+// setCached encodes and caches the value of the key
+func setCached(cache Cache, key string, value any) {
+	if cache == nil {
+		return
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return
+	}
+	cache.Set(key, encoded)
+}
@@ -0,0 +1,68 @@
+package slacker_test
+
+import (
+	"testing"
+
+	"github.com/shomali11/slacker/v2"
+	"github.com/shomali11/slacker/v2/slackertest"
+)
+
+func countCalls(server *slackertest.Server, method string) int {
+	count := 0
+	for _, call := range server.Calls() {
+		if call.Method == method {
+			count++
+		}
+	}
+	return count
+}
+
+func TestMessageEventLookups(t *testing.T) {
+	harness := slackertest.NewHarness()
+	defer harness.Close()
+
+	var event *slacker.MessageEvent
+	harness.Bot().AddCommand(&slacker.CommandDefinition{
+		Command: "whoami",
+		Handler: func(ctx *slacker.CommandContext) {
+			event = ctx.Event()
+		},
+	})
+
+	harness.SendMessage("C123", "U123", "whoami")
+
+	if event == nil || event.UserProfile == nil || event.Channel == nil {
+		t.Fatalf("expected the lookups to be set, got %+v", event)
+	}
+	if event.GetUserProfile() != event.UserProfile || event.GetChannel() != event.Channel {
+		t.Error("expected the accessors to return the looked up values")
+	}
+}
+
+func TestMessageEventLazyLookups(t *testing.T) {
+	harness := slackertest.NewHarness(slacker.WithLazyLookups(true))
+	defer harness.Close()
+
+	lookup := false
+	harness.Bot().AddCommand(&slacker.CommandDefinition{
+		Command: "whoami",
+		Handler: func(ctx *slacker.CommandContext) {
+			if lookup && ctx.Event().GetUserProfile() == nil {
+				t.Error("expected the user profile to be looked up")
+			}
+		},
+	})
+
+	harness.SendMessage("C123", "U123", "whoami")
+
+	if calls := countCalls(harness.Server(), "users.info"); calls != 0 {
+		t.Errorf("expected no lookup, got %d", calls)
+	}
+
+	lookup = true
+	harness.SendMessage("C123", "U123", "whoami")
+
+	if calls := countCalls(harness.Server(), "users.info"); calls != 1 {
+		t.Errorf("expected a single lookup, got %d", calls)
+	}
+}
@@ -0,0 +1,187 @@
+package slacker
+
+import (
+	"time"
+)
+
+const (
+	// MetricCommandMatches counts the commands matched, labeled by command
+	MetricCommandMatches = "slacker_command_matches_total"
+
+	// MetricUnsupportedCommands counts the messages not matching any command
+	MetricUnsupportedCommands = "slacker_unsupported_commands_total"
+
+	// MetricInteractionDispatches counts the interactions dispatched, labeled by interaction and type
+	MetricInteractionDispatches = "slacker_interaction_dispatches_total"
+
+	// MetricEventDispatches counts the events dispatched to event handlers, labeled by event
+	MetricEventDispatches = "slacker_event_dispatches_total"
+
+	// MetricReactionMatches counts the reactions matched, labeled by reaction
+	MetricReactionMatches = "slacker_reaction_matches_total"
+
+	// MetricAppHomeRenders counts the App Home views rendered, labeled by trigger
+	MetricAppHomeRenders = "slacker_app_home_renders_total"
+
+	// MetricConversationSteps counts the conversation replies handled, labeled by conversation and step
+	MetricConversationSteps = "slacker_conversation_steps_total"
+
+	// MetricOptionsSuggestions counts the options suggested to external selects, labeled by action
+	MetricOptionsSuggestions = "slacker_options_suggestions_total"
+
+	// MetricFunctionExecutions counts the Workflow Builder custom steps run, labeled by function
+	MetricFunctionExecutions = "slacker_function_executions_total"
+
+	// MetricJobRuns counts the job runs, labeled by job
+	MetricJobRuns = "slacker_job_runs_total"
+
+	// MetricHandlerDuration observes the handlers' latency in seconds, labeled by
+	// handler type and name: the command, interaction, job, event, reaction,
+	// trigger, conversation, action or function handled
+	MetricHandlerDuration = "slacker_handler_duration_seconds"
+
+	// MetricWriterFailures counts the Writer calls failing, labeled by operation
+	MetricWriterFailures = "slacker_writer_failures_total"
+)
+
+const (
+	// LabelCommand is the command label
+	LabelCommand = "command"
+
+	// LabelInteraction is the interaction label
+	LabelInteraction = "interaction"
+
+	// LabelInteractionType is the interaction type label
+	LabelInteractionType = "interaction_type"
+
+	// LabelEvent is the event type label
+	LabelEvent = "event"
+
+	// LabelReaction is the reaction emoji label
+	LabelReaction = "reaction"
+
+	// LabelTrigger is the App Home trigger label, one of app_home_opened, block_actions or publish
+	LabelTrigger = "trigger"
+
+	// LabelConversation is the conversation label
+	LabelConversation = "conversation"
+
+	// LabelStep is the conversation step label
+	LabelStep = "step"
+
+	// LabelJob is the job label
+	LabelJob = "job"
+
+	// LabelAction is the action ID label of external selects
+	LabelAction = "action"
+
+	// LabelFunction is the callback ID label of Workflow Builder custom steps
+	LabelFunction = "function"
+
+	// LabelHandlerType is the handler type label, one of command, interaction, job, event, reaction,
+	// app_home, conversation, options or function
+	LabelHandlerType = "handler_type"
+
+	// LabelName is the label of the handled command, interaction, job, event,
+	// reaction, trigger, conversation, action or function
+	LabelName = "name"
+
+	// LabelOperation is the Writer operation label, one of post, delete or complete_function
+	LabelOperation = "operation"
+)
+
+const (
+	operationPost             = "post"
+	operationDelete           = "delete"
+	operationCompleteFunction = "complete_function"
+)
+
+// MetricsRecorder records metrics. Implement it to export them to Prometheus,
+// OpenTelemetry or any other monitoring system.
+type MetricsRecorder interface {
+	// IncCounter increments the counter with the labels
+	IncCounter(name string, labels map[string]string)
+
+	// ObserveHistogram adds the value to the histogram with the labels
+	ObserveHistogram(name string, value float64, labels map[string]string)
+}
+
+// newMetrics creates a new metrics structure, recording nothing if the recorder is nil
+func newMetrics(recorder MetricsRecorder) *metrics {
+	return &metrics{recorder: recorder}
+}
+
+// metrics records the metrics slacker exposes
+type metrics struct {
+	recorder MetricsRecorder
+}
+
+func (m *metrics) commandMatched(command string) {
+	m.incCounter(MetricCommandMatches, map[string]string{LabelCommand: command})
+}
+
+func (m *metrics) commandUnsupported() {
+	m.incCounter(MetricUnsupportedCommands, map[string]string{})
+}
+
+func (m *metrics) interactionDispatched(interactionID string, interactionType string) {
+	m.incCounter(MetricInteractionDispatches, map[string]string{
+		LabelInteraction:     interactionID,
+		LabelInteractionType: interactionType,
+	})
+}
+
+func (m *metrics) eventDispatched(eventType string) {
+	m.incCounter(MetricEventDispatches, map[string]string{LabelEvent: eventType})
+}
+
+func (m *metrics) reactionMatched(emoji string) {
+	m.incCounter(MetricReactionMatches, map[string]string{LabelReaction: emoji})
+}
+
+func (m *metrics) appHomeRendered(trigger string) {
+	m.incCounter(MetricAppHomeRenders, map[string]string{LabelTrigger: trigger})
+}
+
+func (m *metrics) conversationStep(conversation string, step string) {
+	m.incCounter(MetricConversationSteps, map[string]string{
+		LabelConversation: conversation,
+		LabelStep:         step,
+	})
+}
+
+func (m *metrics) optionsSuggested(actionID string) {
+	m.incCounter(MetricOptionsSuggestions, map[string]string{LabelAction: actionID})
+}
+
+func (m *metrics) functionExecuted(callbackID string) {
+	m.incCounter(MetricFunctionExecutions, map[string]string{LabelFunction: callbackID})
+}
+
+func (m *metrics) jobRun(job string) {
+	m.incCounter(MetricJobRuns, map[string]string{LabelJob: job})
+}
+
+// handlerDone observes the latency of the handler started at `start`
+func (m *metrics) handlerDone(contextType ContextType, name string, start time.Time) {
+	if m.recorder == nil {
+		return
+	}
+
+	m.recorder.ObserveHistogram(MetricHandlerDuration, time.Since(start).Seconds(), map[string]string{
+		LabelHandlerType: contextType.String(),
+		LabelName:        name,
+	})
+}
+
+func (m *metrics) writerFailed(operation string) {
+	m.incCounter(MetricWriterFailures, map[string]string{LabelOperation: operation})
+}
+
+func (m *metrics) incCounter(name string, labels map[string]string) {
+	if m.recorder == nil {
+		return
+	}
+
+	m.recorder.IncCounter(name, labels)
+}
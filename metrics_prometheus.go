@@ -0,0 +1,199 @@
+package slacker
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	prometheusContentType = "text/plain; version=0.0.4; charset=utf-8"
+)
+
+// labelValueEscaper escapes label values as the Prometheus text format expects
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// DefaultHistogramBuckets are the default upper bounds, in seconds, of the histogram buckets
+var DefaultHistogramBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// NewPrometheusRecorder creates an in-memory recorder serving its metrics in
+// the Prometheus text format. Uses DefaultHistogramBuckets if none are given.
+func NewPrometheusRecorder(buckets ...float64) *PrometheusRecorder {
+	if len(buckets) == 0 {
+		buckets = DefaultHistogramBuckets
+	}
+
+	sorted := make([]float64, len(buckets))
+	copy(sorted, buckets)
+	sort.Float64s(sorted)
+
+	return &PrometheusRecorder{
+		buckets:    sorted,
+		counters:   make(map[string]map[string]*counterSeries),
+		histograms: make(map[string]map[string]*histogramSeries),
+	}
+}
+
+// PrometheusRecorder is a MetricsRecorder and an http.Handler exposing the
+// recorded metrics to be scraped by Prometheus
+type PrometheusRecorder struct {
+	buckets    []float64
+	mutex      sync.Mutex
+	counters   map[string]map[string]*counterSeries
+	histograms map[string]map[string]*histogramSeries
+}
+
+type counterSeries struct {
+	labels map[string]string
+	value  float64
+}
+
+type histogramSeries struct {
+	labels map[string]string
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+// IncCounter increments the counter with the labels
+func (r *PrometheusRecorder) IncCounter(name string, labels map[string]string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	series, ok := r.counters[name]
+	if !ok {
+		series = make(map[string]*counterSeries)
+		r.counters[name] = series
+	}
+
+	key := formatLabels(labels, "", "")
+	counter, ok := series[key]
+	if !ok {
+		counter = &counterSeries{labels: copyLabels(labels)}
+		series[key] = counter
+	}
+	counter.value++
+}
+
+// ObserveHistogram adds the value to the histogram with the labels
+func (r *PrometheusRecorder) ObserveHistogram(name string, value float64, labels map[string]string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	series, ok := r.histograms[name]
+	if !ok {
+		series = make(map[string]*histogramSeries)
+		r.histograms[name] = series
+	}
+
+	key := formatLabels(labels, "", "")
+	histogram, ok := series[key]
+	if !ok {
+		histogram = &histogramSeries{labels: copyLabels(labels), counts: make([]uint64, len(r.buckets))}
+		series[key] = histogram
+	}
+
+	for i, bound := range r.buckets {
+		if value <= bound {
+			histogram.counts[i]++
+		}
+	}
+	histogram.count++
+	histogram.sum += value
+}
+
+// ServeHTTP writes the metrics in the Prometheus text format
+func (r *PrometheusRecorder) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", prometheusContentType)
+	r.WriteTo(w)
+}
+
+// WriteTo writes the metrics in the Prometheus text format
+func (r *PrometheusRecorder) WriteTo(w io.Writer) (int64, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var builder strings.Builder
+
+	for _, name := range sortedKeys(r.counters) {
+		fmt.Fprintf(&builder, "# TYPE %s counter\n", name)
+
+		series := r.counters[name]
+		for _, key := range sortedKeys(series) {
+			fmt.Fprintf(&builder, "%s%s %s\n", name, key, formatFloat(series[key].value))
+		}
+	}
+
+	for _, name := range sortedKeys(r.histograms) {
+		fmt.Fprintf(&builder, "# TYPE %s histogram\n", name)
+
+		series := r.histograms[name]
+		for _, key := range sortedKeys(series) {
+			histogram := series[key]
+			for i, bound := range r.buckets {
+				labels := formatLabels(histogram.labels, "le", formatFloat(bound))
+				fmt.Fprintf(&builder, "%s_bucket%s %d\n", name, labels, histogram.counts[i])
+			}
+
+			labels := formatLabels(histogram.labels, "le", "+Inf")
+			fmt.Fprintf(&builder, "%s_bucket%s %d\n", name, labels, histogram.count)
+			fmt.Fprintf(&builder, "%s_sum%s %s\n", name, key, formatFloat(histogram.sum))
+			fmt.Fprintf(&builder, "%s_count%s %d\n", name, key, histogram.count)
+		}
+	}
+
+	written, err := io.WriteString(w, builder.String())
+	return int64(written), err
+}
+
+// formatLabels formats the labels sorted by name, along with an extra label if its name is not empty
+func formatLabels(labels map[string]string, extraName string, extraValue string) string {
+	names := sortedKeys(labels)
+	if len(names) == 0 && len(extraName) == 0 {
+		return ""
+	}
+
+	pairs := make([]string, 0, len(names)+1)
+	for _, name := range names {
+		pairs = append(pairs, name+"="+quoteLabelValue(labels[name]))
+	}
+
+	if len(extraName) > 0 {
+		pairs = append(pairs, extraName+"="+quoteLabelValue(extraValue))
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+// quoteLabelValue quotes the label value, escaping only backslashes, double quotes and line feeds
+func quoteLabelValue(value string) string {
+	return `"` + labelValueEscaper.Replace(value) + `"`
+}
+
+func formatFloat(value float64) string {
+	if math.IsInf(value, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+func copyLabels(labels map[string]string) map[string]string {
+	result := make(map[string]string, len(labels))
+	for name, value := range labels {
+		result[name] = value
+	}
+	return result
+}
+
+func sortedKeys[V any](values map[string]V) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,54 @@
+package slacker_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shomali11/slacker/v2"
+	"github.com/shomali11/slacker/v2/slackertest"
+)
+
+func TestPrometheusRecorderEscaping(t *testing.T) {
+	recorder := slacker.NewPrometheusRecorder()
+	recorder.IncCounter("test_total", map[string]string{"value": "a\\b \"c\"\nd é"})
+
+	var builder strings.Builder
+	if _, err := recorder.WriteTo(&builder); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	expected := `test_total{value="a\\b \"c\"\nd é"} 1`
+	if !strings.Contains(builder.String(), expected) {
+		t.Errorf("expected %s in\n%s", expected, builder.String())
+	}
+}
+
+func TestHandlerDurationLabels(t *testing.T) {
+	recorder := slacker.NewPrometheusRecorder()
+	harness := slackertest.NewHarness(slacker.WithMetricsRecorder(recorder))
+	defer harness.Close()
+
+	harness.Bot().AddCommand(&slacker.CommandDefinition{
+		Command: "ping",
+		Handler: func(ctx *slacker.CommandContext) {},
+	})
+	harness.Bot().AddReaction(&slacker.ReactionDefinition{
+		Emoji:   "eyes",
+		Handler: func(ctx *slacker.ReactionContext) {},
+	})
+
+	timeStamp := harness.SendMessage("C123", "U123", "ping")
+	harness.SendReaction("C123", "U123", timeStamp, "eyes")
+
+	var builder strings.Builder
+	recorder.WriteTo(&builder)
+
+	for _, expected := range []string{
+		`slacker_handler_duration_seconds_count{handler_type="command",name="ping"} 1`,
+		`slacker_handler_duration_seconds_count{handler_type="reaction",name="eyes"} 1`,
+	} {
+		if !strings.Contains(builder.String(), expected) {
+			t.Errorf("expected %s in\n%s", expected, builder.String())
+		}
+	}
+}
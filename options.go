@@ -37,6 +37,14 @@ func WithLogger(logger Logger) ClientOption {
 	}
 }
 
+// WithStructuredLogger sets the structured logger used by slacker. It takes
+// precedence over WithLogger.
+func WithStructuredLogger(logger StructuredLogger) ClientOption {
+	return func(defaults *clientOptions) {
+		defaults.StructuredLogger = logger
+	}
+}
+
 // WithCronLocation overrides the timezone of the cron instance.
 func WithCronLocation(location *time.Location) ClientOption {
 	return func(defaults *clientOptions) {
@@ -44,28 +52,188 @@ func WithCronLocation(location *time.Location) ClientOption {
 	}
 }
 
+// WithTransportMode instructs Slacker on how to receive events from Slack.
+func WithTransportMode(mode TransportMode) ClientOption {
+	return func(defaults *clientOptions) {
+		defaults.TransportMode = mode
+	}
+}
+
+// WithSigningSecret sets the secret used to verify requests received over HTTP
+func WithSigningSecret(signingSecret string) ClientOption {
+	return func(defaults *clientOptions) {
+		defaults.SigningSecret = signingSecret
+	}
+}
+
+// WithHTTPAddress sets the address to listen on when receiving events over HTTP
+func WithHTTPAddress(address string) ClientOption {
+	return func(defaults *clientOptions) {
+		defaults.HTTPAddress = address
+	}
+}
+
+// WithRoleProvider sets the provider resolving user roles for authorization
+func WithRoleProvider(provider RoleProvider) ClientOption {
+	return func(defaults *clientOptions) {
+		defaults.RoleProvider = provider
+	}
+}
+
+// WithCache sets the cache used for channel and user lookups. Pass nil to disable caching.
+func WithCache(cache Cache) ClientOption {
+	return func(defaults *clientOptions) {
+		defaults.Cache = cache
+	}
+}
+
+// WithLazyLookups defers channel and user lookups until MessageEvent.GetChannel
+// or MessageEvent.GetUserProfile is called. The deprecated MessageEvent.Channel
+// and MessageEvent.UserProfile fields are then nil until the lookup happens.
+func WithLazyLookups(lazyLookups bool) ClientOption {
+	return func(defaults *clientOptions) {
+		defaults.LazyLookups = lazyLookups
+	}
+}
+
+// WithShutdownTimeout sets how long to wait for in-flight command, interaction
+// and job handlers once the context passed to Listen is done, 5 seconds by
+// default. Handlers still running after the timeout have their context canceled.
+func WithShutdownTimeout(timeout time.Duration) ClientOption {
+	return func(defaults *clientOptions) {
+		defaults.ShutdownTimeout = timeout
+	}
+}
+
+// WithWorkerPool dispatches events to a fixed number of workers through a
+// queue holding up to `queueSize` events instead of a goroutine per event
+func WithWorkerPool(workers int, queueSize int) ClientOption {
+	return func(defaults *clientOptions) {
+		defaults.Workers = workers
+		defaults.QueueSize = queueSize
+	}
+}
+
+// WithOverflowPolicy instructs the worker pool on how to handle events once its queue is full.
+func WithOverflowPolicy(policy OverflowPolicy) ClientOption {
+	return func(defaults *clientOptions) {
+		defaults.OverflowPolicy = policy
+	}
+}
+
+// WithChannelSerialization handles the events of a channel in order, one at a
+// time. Requires a worker pool.
+func WithChannelSerialization(serialize bool) ClientOption {
+	return func(defaults *clientOptions) {
+		defaults.ChannelSerialization = serialize
+	}
+}
+
+// WithBusyMessage sets the message replied with OverflowPolicyReplyBusy
+func WithBusyMessage(message string) ClientOption {
+	return func(defaults *clientOptions) {
+		defaults.BusyMessage = message
+	}
+}
+
+// WithErrorReplies replies with an ephemeral error when a command or interaction
+// handler fails. The details of panics are not shared with users.
+func WithErrorReplies(errorReplies bool) ClientOption {
+	return func(defaults *clientOptions) {
+		defaults.ErrorReplies = errorReplies
+	}
+}
+
+// WithMetricsRecorder sets the recorder of command, interaction and job metrics
+func WithMetricsRecorder(recorder MetricsRecorder) ClientOption {
+	return func(defaults *clientOptions) {
+		defaults.MetricsRecorder = recorder
+	}
+}
+
+// WithTracer sets the tracer starting a span for every event, its lookups,
+// matching, middlewares, handler and Writer calls
+func WithTracer(tracer Tracer) ClientOption {
+	return func(defaults *clientOptions) {
+		defaults.Tracer = tracer
+	}
+}
+
+// WithStore sets the store shared by command, interaction and job handlers, in
+// memory by default. Pass a FileStore to persist state across restarts, or
+// implement Store to plug in an external store.
+func WithStore(store Store) ClientOption {
+	return func(defaults *clientOptions) {
+		defaults.Store = store
+	}
+}
+
+// WithConversationStore sets the store persisting the state of ongoing
+// conversations, in memory by default. Use NewStoreConversationStore to keep
+// them in the Store.
+func WithConversationStore(store ConversationStore) ClientOption {
+	return func(defaults *clientOptions) {
+		defaults.ConversationStore = store
+	}
+}
+
 type clientOptions struct {
-	APIURL       string
-	Debug        bool
-	BotMode      BotMode
-	Logger       Logger
-	CronLocation *time.Location
+	APIURL               string
+	Debug                bool
+	BotMode              BotMode
+	Logger               Logger
+	StructuredLogger     StructuredLogger
+	CronLocation         *time.Location
+	TransportMode        TransportMode
+	SigningSecret        string
+	HTTPAddress          string
+	RoleProvider         RoleProvider
+	Cache                Cache
+	LazyLookups          bool
+	ShutdownTimeout      time.Duration
+	Workers              int
+	QueueSize            int
+	OverflowPolicy       OverflowPolicy
+	ChannelSerialization bool
+	BusyMessage          string
+	ErrorReplies         bool
+	MetricsRecorder      MetricsRecorder
+	Tracer               Tracer
+	Store                Store
+	ConversationStore    ConversationStore
 }
 
 func newClientOptions(options ...ClientOption) *clientOptions {
 	config := &clientOptions{
-		APIURL:       slack.APIURL,
-		Debug:        false,
-		BotMode:      BotModeIgnoreAll,
-		CronLocation: time.Local,
+		APIURL:          slack.APIURL,
+		Debug:           false,
+		BotMode:         BotModeIgnoreAll,
+		CronLocation:    time.Local,
+		TransportMode:   TransportModeSocket,
+		HTTPAddress:     ":3000",
+		Cache:           NewLRUCache(defaultCacheSize, defaultCacheTTL),
+		OverflowPolicy:  OverflowPolicyBlock,
+		BusyMessage:     defaultBusyMessage,
+		ShutdownTimeout: defaultShutdownTimeout,
 	}
 
 	for _, option := range options {
 		option(config)
 	}
 
-	if config.Logger == nil {
-		config.Logger = newBuiltinLogger(config.Debug)
+	if config.StructuredLogger == nil {
+		if config.Logger == nil {
+			config.Logger = newBuiltinLogger(config.Debug)
+		}
+		config.StructuredLogger = NewPrintfLogger(config.Logger)
+	}
+
+	if config.Store == nil {
+		config.Store = NewMemoryStore()
+	}
+
+	if config.ConversationStore == nil {
+		config.ConversationStore = NewMemoryConversationStore()
 	}
 	return config
 }
@@ -94,6 +262,14 @@ func WithReplace(originalMessageTS string) ReplyOption {
 	}
 }
 
+// WithReplaceOriginal replaces the message an interaction came from, or the last
+// response of a slash command sent through its response URL
+func WithReplaceOriginal() ReplyOption {
+	return func(defaults *replyOptions) {
+		defaults.ReplaceOriginal = true
+	}
+}
+
 // WithEphemeral sets the message as ephemeral
 func WithEphemeral() ReplyOption {
 	return func(defaults *replyOptions) {
@@ -112,6 +288,7 @@ type replyOptions struct {
 	Attachments      []slack.Attachment
 	InThread         *bool
 	ReplaceMessageTS string
+	ReplaceOriginal  bool
 	IsEphemeral      bool
 	ScheduleTime     *time.Time
 }
@@ -167,12 +344,30 @@ func SetSchedule(timestamp time.Time) PostOption {
 	}
 }
 
+// SetResponseURL sends the message through the response URL of a slash command
+// or interaction instead of the channel. Messages are visible to the channel,
+// unless set as ephemeral. Threads and schedules are not supported.
+func SetResponseURL(responseURL string) PostOption {
+	return func(defaults *postOptions) {
+		defaults.ResponseURL = responseURL
+	}
+}
+
+// SetReplaceOriginal replaces the last message sent through the response URL
+func SetReplaceOriginal() PostOption {
+	return func(defaults *postOptions) {
+		defaults.ReplaceOriginal = true
+	}
+}
+
 type postOptions struct {
 	Attachments      []slack.Attachment
 	ThreadTS         string
 	ReplaceMessageTS string
 	EphemeralUserID  string
 	ScheduleTime     *time.Time
+	ResponseURL      string
+	ReplaceOriginal  bool
 }
 
 // newPostOptions builds our PostOptions from zero or more PostOption.
@@ -186,3 +381,83 @@ func newPostOptions(options ...PostOption) *postOptions {
 	}
 	return config
 }
+
+// UserGroupRoleProviderOption an option for user group role provider values
+type UserGroupRoleProviderOption func(*userGroupRoleProviderOptions)
+
+// WithUserGroupCache sets the cache of user group memberships. Defaults to an
+// in-memory cache holding memberships for 5 minutes, nil disables caching.
+func WithUserGroupCache(cache Cache) UserGroupRoleProviderOption {
+	return func(defaults *userGroupRoleProviderOptions) {
+		defaults.Cache = cache
+	}
+}
+
+type userGroupRoleProviderOptions struct {
+	Cache Cache
+}
+
+// newUserGroupRoleProviderOptions builds our UserGroupRoleProviderOptions from zero or more UserGroupRoleProviderOption.
+func newUserGroupRoleProviderOptions(options ...UserGroupRoleProviderOption) *userGroupRoleProviderOptions {
+	config := &userGroupRoleProviderOptions{
+		Cache: NewLRUCache(defaultCacheSize, defaultCacheTTL),
+	}
+
+	for _, option := range options {
+		option(config)
+	}
+	return config
+}
+
+// RateLimiterOption an option for rate limiter values
+type RateLimiterOption func(*rateLimiterOptions)
+
+// WithRateLimitKey sets what invocations are grouped by. Defaults to RateLimitKeyUser
+func WithRateLimitKey(key RateLimitKey) RateLimiterOption {
+	return func(defaults *rateLimiterOptions) {
+		defaults.Key = key
+	}
+}
+
+// WithRateLimitBurst sets how many invocations can happen at once. Defaults to the limit
+func WithRateLimitBurst(burst int) RateLimiterOption {
+	return func(defaults *rateLimiterOptions) {
+		defaults.Burst = burst
+	}
+}
+
+// WithRateLimitMessage sets the ephemeral reply sent when the limit is exceeded
+func WithRateLimitMessage(message string) RateLimiterOption {
+	return func(defaults *rateLimiterOptions) {
+		defaults.Message = message
+	}
+}
+
+// WithRateLimitClock overrides the clock used to refill tokens (for testing)
+func WithRateLimitClock(clock func() time.Time) RateLimiterOption {
+	return func(defaults *rateLimiterOptions) {
+		defaults.Clock = clock
+	}
+}
+
+type rateLimiterOptions struct {
+	Key     RateLimitKey
+	Burst   int
+	Message string
+	Clock   func() time.Time
+}
+
+// newRateLimiterOptions builds our RateLimiterOptions from zero or more RateLimiterOption.
+func newRateLimiterOptions(limit int, options ...RateLimiterOption) *rateLimiterOptions {
+	config := &rateLimiterOptions{
+		Key:     RateLimitKeyUser,
+		Burst:   limit,
+		Message: defaultRateLimitMessage,
+		Clock:   time.Now,
+	}
+
+	for _, option := range options {
+		option(config)
+	}
+	return config
+}
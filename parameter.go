@@ -0,0 +1,248 @@
+package slacker
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shomali11/proper"
+)
+
+const (
+	defaultDateLayout       = "2006-01-02"
+	parameterFormat         = "%s:%s"
+	optionalParameterFormat = "[%s]"
+	enumSeparator           = "|"
+)
+
+var (
+	userMentionRegex    = regexp.MustCompile(`^<@([UW][A-Z0-9]+)(\|[^>]*)?>$`)
+	channelMentionRegex = regexp.MustCompile(`^<#([CG][A-Z0-9]+)(\|[^>]*)?>$`)
+)
+
+// ParameterType is the type of a command parameter
+type ParameterType int
+
+const (
+	// ParameterTypeString accepts any text
+	ParameterTypeString ParameterType = iota
+
+	// ParameterTypeInteger accepts whole numbers. For instance, `42`
+	ParameterTypeInteger
+
+	// ParameterTypeFloat accepts decimal numbers. For instance, `4.2`
+	ParameterTypeFloat
+
+	// ParameterTypeBoolean accepts boolean values. For instance, `true` or `false`
+	ParameterTypeBoolean
+
+	// ParameterTypeDuration accepts durations. For instance, `1h30m`
+	ParameterTypeDuration
+
+	// ParameterTypeEnum accepts one of the parameter's `Values`, ignoring case
+	ParameterTypeEnum
+
+	// ParameterTypeUser accepts user mentions. For instance, `@john`
+	ParameterTypeUser
+
+	// ParameterTypeChannel accepts channel mentions. For instance, `#general`
+	ParameterTypeChannel
+
+	// ParameterTypeDate accepts dates formatted using the parameter's `Layout`
+	ParameterTypeDate
+)
+
+// String returns the name of the type as displayed in help and usage messages
+func (t ParameterType) String() string {
+	switch t {
+	case ParameterTypeInteger:
+		return "integer"
+	case ParameterTypeFloat:
+		return "number"
+	case ParameterTypeBoolean:
+		return "boolean"
+	case ParameterTypeDuration:
+		return "duration"
+	case ParameterTypeEnum:
+		return "enum"
+	case ParameterTypeUser:
+		return "user"
+	case ParameterTypeChannel:
+		return "channel"
+	case ParameterTypeDate:
+		return "date"
+	default:
+		return "string"
+	}
+}
+
+// ParameterDefinition structure contains definition of a command parameter
+type ParameterDefinition struct {
+	// Name matches the parameter in the command format. For instance, `count` in `repeat {count}`
+	Name        string
+	Type        ParameterType
+	Description string
+
+	// Optional allows the parameter to be omitted. Parameters are required by default.
+	Optional bool
+
+	// Values lists the accepted values of an enum parameter
+	Values []string
+
+	// Min and Max bound the values of integer and float parameters when set
+	Min *float64
+	Max *float64
+
+	// Layout is the date format of a date parameter. Defaults to `2006-01-02`
+	Layout string
+
+	// Validate performs additional validation on the converted value.
+	// For instance, an int for integer parameters or a time.Duration for duration parameters.
+	Validate func(value any) error
+}
+
+// Usage returns the parameter as displayed in help and usage messages
+func (p *ParameterDefinition) Usage() string {
+	typeName := p.Type.String()
+	if p.Type == ParameterTypeEnum && len(p.Values) > 0 {
+		typeName = strings.Join(p.Values, enumSeparator)
+	}
+
+	usage := fmt.Sprintf(parameterFormat, p.Name, typeName)
+	if p.Optional {
+		usage = fmt.Sprintf(optionalParameterFormat, usage)
+	}
+	return usage
+}
+
+// Parse converts and validates a raw parameter value
+func (p *ParameterDefinition) Parse(value string) (any, error) {
+	var parsed any
+	var err error
+
+	switch p.Type {
+	case ParameterTypeInteger:
+		parsed, err = strconv.Atoi(value)
+	case ParameterTypeFloat:
+		parsed, err = strconv.ParseFloat(value, 64)
+	case ParameterTypeBoolean:
+		parsed, err = strconv.ParseBool(value)
+	case ParameterTypeDuration:
+		parsed, err = time.ParseDuration(value)
+	case ParameterTypeEnum:
+		parsed, err = p.parseEnum(value)
+	case ParameterTypeUser:
+		parsed, err = parseUserMention(value)
+	case ParameterTypeChannel:
+		parsed, err = parseChannelMention(value)
+	case ParameterTypeDate:
+		parsed, err = time.Parse(p.layout(), value)
+	default:
+		parsed = value
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("`%s` must be a valid %s", p.Name, p.typeDescription())
+	}
+
+	if err := p.checkBounds(parsed); err != nil {
+		return nil, err
+	}
+
+	if p.Validate != nil {
+		if err := p.Validate(parsed); err != nil {
+			return nil, fmt.Errorf("`%s` is invalid: %v", p.Name, err)
+		}
+	}
+	return parsed, nil
+}
+
+func (p *ParameterDefinition) parseEnum(value string) (string, error) {
+	for _, allowed := range p.Values {
+		if strings.EqualFold(allowed, value) {
+			return allowed, nil
+		}
+	}
+	return empty, fmt.Errorf("unexpected value %s", value)
+}
+
+func (p *ParameterDefinition) checkBounds(value any) error {
+	var number float64
+	switch v := value.(type) {
+	case int:
+		number = float64(v)
+	case float64:
+		number = v
+	default:
+		return nil
+	}
+
+	if p.Min != nil && number < *p.Min {
+		return fmt.Errorf("`%s` must be at least %v", p.Name, *p.Min)
+	}
+
+	if p.Max != nil && number > *p.Max {
+		return fmt.Errorf("`%s` must be at most %v", p.Name, *p.Max)
+	}
+	return nil
+}
+
+func (p *ParameterDefinition) layout() string {
+	if len(p.Layout) == 0 {
+		return defaultDateLayout
+	}
+	return p.Layout
+}
+
+func (p *ParameterDefinition) typeDescription() string {
+	switch p.Type {
+	case ParameterTypeEnum:
+		return "value, one of " + strings.Join(p.Values, ", ")
+	case ParameterTypeUser:
+		return "user mention"
+	case ParameterTypeChannel:
+		return "channel mention"
+	case ParameterTypeDate:
+		return "date formatted as " + p.layout()
+	default:
+		return p.Type.String()
+	}
+}
+
+// validateParameters ensures the matched parameters satisfy the command's parameter definitions
+func validateParameters(definitions []*ParameterDefinition, properties *proper.Properties) error {
+	for _, definition := range definitions {
+		value := properties.StringParam(definition.Name, empty)
+		if len(value) == 0 {
+			if definition.Optional {
+				continue
+			}
+			return fmt.Errorf("`%s` is required", definition.Name)
+		}
+
+		if _, err := definition.Parse(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseUserMention extracts the user ID from a mention. For instance, `<@U123|john>`
+func parseUserMention(value string) (string, error) {
+	matches := userMentionRegex.FindStringSubmatch(value)
+	if len(matches) == 0 {
+		return empty, fmt.Errorf("invalid user mention %s", value)
+	}
+	return matches[1], nil
+}
+
+// parseChannelMention extracts the channel ID from a mention. For instance, `<#C123|general>`
+func parseChannelMention(value string) (string, error) {
+	matches := channelMentionRegex.FindStringSubmatch(value)
+	if len(matches) == 0 {
+		return empty, fmt.Errorf("invalid channel mention %s", value)
+	}
+	return matches[1], nil
+}
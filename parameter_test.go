@@ -0,0 +1,128 @@
+package slacker_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shomali11/slacker/v2"
+	"github.com/shomali11/slacker/v2/slackertest"
+	"github.com/slack-go/slack"
+)
+
+func TestParameterParse(t *testing.T) {
+	limit := 10.0
+	tests := []struct {
+		name       string
+		definition *slacker.ParameterDefinition
+		value      string
+		expected   any
+		invalid    bool
+	}{
+		{name: "string", definition: &slacker.ParameterDefinition{Name: "p"}, value: "text", expected: "text"},
+		{name: "integer", definition: &slacker.ParameterDefinition{Name: "p", Type: slacker.ParameterTypeInteger}, value: "42", expected: 42},
+		{name: "invalid integer", definition: &slacker.ParameterDefinition{Name: "p", Type: slacker.ParameterTypeInteger}, value: "4.2", invalid: true},
+		{name: "float", definition: &slacker.ParameterDefinition{Name: "p", Type: slacker.ParameterTypeFloat}, value: "4.2", expected: 4.2},
+		{name: "boolean", definition: &slacker.ParameterDefinition{Name: "p", Type: slacker.ParameterTypeBoolean}, value: "true", expected: true},
+		{name: "duration", definition: &slacker.ParameterDefinition{Name: "p", Type: slacker.ParameterTypeDuration}, value: "1h30m", expected: 90 * time.Minute},
+		{name: "enum ignoring case", definition: &slacker.ParameterDefinition{Name: "p", Type: slacker.ParameterTypeEnum, Values: []string{"low", "high"}}, value: "HIGH", expected: "high"},
+		{name: "unexpected enum", definition: &slacker.ParameterDefinition{Name: "p", Type: slacker.ParameterTypeEnum, Values: []string{"low", "high"}}, value: "medium", invalid: true},
+		{name: "user mention", definition: &slacker.ParameterDefinition{Name: "p", Type: slacker.ParameterTypeUser}, value: "<@U123|john>", expected: "U123"},
+		{name: "invalid user mention", definition: &slacker.ParameterDefinition{Name: "p", Type: slacker.ParameterTypeUser}, value: "john", invalid: true},
+		{name: "channel mention", definition: &slacker.ParameterDefinition{Name: "p", Type: slacker.ParameterTypeChannel}, value: "<#C123|general>", expected: "C123"},
+		{name: "private channel mention", definition: &slacker.ParameterDefinition{Name: "p", Type: slacker.ParameterTypeChannel}, value: "<#G123|secret>", expected: "G123"},
+		{name: "invalid channel mention", definition: &slacker.ParameterDefinition{Name: "p", Type: slacker.ParameterTypeChannel}, value: "<#D123>", invalid: true},
+		{name: "date", definition: &slacker.ParameterDefinition{Name: "p", Type: slacker.ParameterTypeDate}, value: "2024-02-29", expected: time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{name: "date with layout", definition: &slacker.ParameterDefinition{Name: "p", Type: slacker.ParameterTypeDate, Layout: "02/01/2006"}, value: "2024-02-29", invalid: true},
+		{name: "within bounds", definition: &slacker.ParameterDefinition{Name: "p", Type: slacker.ParameterTypeInteger, Max: &limit}, value: "10", expected: 10},
+		{name: "out of bounds", definition: &slacker.ParameterDefinition{Name: "p", Type: slacker.ParameterTypeFloat, Max: &limit}, value: "10.5", invalid: true},
+		{
+			name: "custom validation",
+			definition: &slacker.ParameterDefinition{Name: "p", Validate: func(value any) error {
+				if value.(string) != "ok" {
+					return errors.New("not ok")
+				}
+				return nil
+			}},
+			value:   "ko",
+			invalid: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			parsed, err := test.definition.Parse(test.value)
+			if test.invalid {
+				if err == nil {
+					t.Fatalf("expected an error, got %v", parsed)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if parsed != test.expected {
+				t.Errorf("expected %v, got %v", test.expected, parsed)
+			}
+		})
+	}
+}
+
+func TestParameterUsage(t *testing.T) {
+	definition := &slacker.ParameterDefinition{Name: "level", Type: slacker.ParameterTypeEnum, Values: []string{"low", "high"}, Optional: true}
+	if usage := definition.Usage(); usage != "[level:low|high]" {
+		t.Errorf("unexpected usage %s", usage)
+	}
+}
+
+func TestParameterValidationReply(t *testing.T) {
+	harness := slackertest.NewHarness()
+	defer harness.Close()
+
+	handled := false
+	harness.Bot().AddCommand(&slacker.CommandDefinition{
+		Command: "repeat {count}",
+		Parameters: []*slacker.ParameterDefinition{
+			{Name: "count", Type: slacker.ParameterTypeInteger, Description: "How many times to repeat"},
+		},
+		Handler: func(ctx *slacker.CommandContext) {
+			handled = true
+		},
+	})
+
+	harness.SendMessage("C123", "U123", "repeat many")
+
+	if handled {
+		t.Error("expected the handler not to run")
+	}
+
+	messages := harness.Messages()
+	if len(messages) != 1 {
+		t.Fatalf("expected 1 message, got %d", len(messages))
+	}
+
+	text := blocksText(messages[0].Blocks)
+	if !strings.Contains(text, "`count` must be a valid integer") || !strings.Contains(text, "repeat") {
+		t.Errorf("expected the usage of the command, got %q", text)
+	}
+
+	harness.Reset()
+	harness.SendMessage("C123", "U123", "repeat 3")
+
+	if !handled || len(harness.Messages()) != 0 {
+		t.Errorf("expected the handler to run without a usage reply, got %+v", harness.Messages())
+	}
+}
+
+// blocksText returns the text of the section blocks
+func blocksText(blocks []slack.Block) string {
+	text := ""
+	for _, block := range blocks {
+		if section, ok := block.(*slack.SectionBlock); ok && section.Text != nil {
+			text += section.Text.Text + "\n"
+		}
+	}
+	return text
+}
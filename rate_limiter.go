@@ -0,0 +1,166 @@
+package slacker
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultRateLimitMessage = "You are doing that too often. Please slow down and try again later."
+	rateLimitKeySeparator   = ":"
+	maxIdleBuckets          = 10000
+)
+
+// RateLimitKey selects what invocations are grouped by when rate limiting.
+// Keys can be combined, for instance `RateLimitKeyUser | RateLimitKeyCommand`
+// limits every user separately for every command.
+type RateLimitKey int
+
+const (
+	// RateLimitKeyUser limits invocations per user
+	RateLimitKeyUser RateLimitKey = 1 << iota
+
+	// RateLimitKeyChannel limits invocations per channel
+	RateLimitKeyChannel
+
+	// RateLimitKeyCommand limits invocations per command or interaction
+	RateLimitKeyCommand
+)
+
+// NewRateLimiter creates a token bucket rate limiter that allows `limit` invocations
+// per `interval`. Buckets start full and are refilled continuously.
+func NewRateLimiter(limit int, interval time.Duration, options ...RateLimiterOption) *RateLimiter {
+	rateLimiterOptions := newRateLimiterOptions(limit, options...)
+	return &RateLimiter{
+		capacity: float64(rateLimiterOptions.Burst),
+		rate:     float64(limit) / interval.Seconds(),
+		key:      rateLimiterOptions.Key,
+		message:  rateLimiterOptions.Message,
+		clock:    rateLimiterOptions.Clock,
+		buckets:  make(map[string]*bucket),
+	}
+}
+
+// RateLimiter limits how often commands and interactions can be invoked.
+// Add its middlewares to the bot, a command group or a single definition to
+// control the scope of the limit.
+type RateLimiter struct {
+	capacity float64
+	rate     float64
+	key      RateLimitKey
+	message  string
+	clock    func() time.Time
+	mutex    sync.Mutex
+	buckets  map[string]*bucket
+}
+
+// bucket tracks the remaining tokens of a single key
+type bucket struct {
+	tokens    float64
+	updatedAt time.Time
+}
+
+// Allow consumes a token for the given key, returning false if none are left
+func (l *RateLimiter) Allow(key string) bool {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := l.clock()
+	b, ok := l.buckets[key]
+	if !ok {
+		l.prune(now)
+		b = &bucket{tokens: l.capacity, updatedAt: now}
+		l.buckets[key] = b
+	}
+
+	b.tokens += now.Sub(b.updatedAt).Seconds() * l.rate
+	if b.tokens > l.capacity {
+		b.tokens = l.capacity
+	}
+	b.updatedAt = now
+
+	if b.tokens < 1 {
+		return false
+	}
+
+	b.tokens--
+	return true
+}
+
+// CommandMiddleware returns a middleware replying with an ephemeral message
+// instead of running the command when the limit is exceeded
+func (l *RateLimiter) CommandMiddleware() CommandMiddlewareHandler {
+	return func(next CommandHandler) CommandHandler {
+		return func(ctx *CommandContext) {
+			command := empty
+			if ctx.Definition() != nil {
+				command = ctx.Definition().Command
+			}
+
+			if l.Allow(l.buildKey(ctx.Event().UserID, ctx.Event().ChannelID, command)) {
+				next(ctx)
+				return
+			}
+
+			ctx.StructuredLogger().Debug("rate limited")
+			ctx.Response().Reply(l.message, WithEphemeral())
+		}
+	}
+}
+
+// InteractionMiddleware returns a middleware replying with an ephemeral message
+// instead of running the interaction when the limit is exceeded
+func (l *RateLimiter) InteractionMiddleware() InteractionMiddlewareHandler {
+	return func(next InteractionHandler) InteractionHandler {
+		return func(ctx *InteractionContext) {
+			interaction := empty
+			if ctx.Definition() != nil {
+				interaction = ctx.Definition().name()
+			}
+
+			callback := ctx.Callback()
+			if l.Allow(l.buildKey(callback.User.ID, callback.Channel.ID, interaction)) {
+				next(ctx)
+				return
+			}
+
+			ctx.StructuredLogger().Debug("rate limited")
+
+			// Interactions such as shortcuts and view submissions do not have a channel to reply to
+			if len(callback.Channel.ID) == 0 {
+				return
+			}
+			ctx.Response().Reply(l.message, WithEphemeral())
+		}
+	}
+}
+
+func (l *RateLimiter) buildKey(userID string, channelID string, command string) string {
+	parts := make([]string, 0)
+	if l.key&RateLimitKeyUser != 0 {
+		parts = append(parts, userID)
+	}
+
+	if l.key&RateLimitKeyChannel != 0 {
+		parts = append(parts, channelID)
+	}
+
+	if l.key&RateLimitKeyCommand != 0 {
+		parts = append(parts, command)
+	}
+	return strings.Join(parts, rateLimitKeySeparator)
+}
+
+// prune forgets buckets that have refilled completely once too many are tracked
+func (l *RateLimiter) prune(now time.Time) {
+	if len(l.buckets) < maxIdleBuckets {
+		return
+	}
+
+	for key, b := range l.buckets {
+		if b.tokens+now.Sub(b.updatedAt).Seconds()*l.rate >= l.capacity {
+			delete(l.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package slacker_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shomali11/slacker/v2"
+	"github.com/shomali11/slacker/v2/slackertest"
+)
+
+// fakeClock is a clock advanced manually
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Advance(duration time.Duration) {
+	c.now = c.now.Add(duration)
+}
+
+func TestRateLimiterAllow(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	limiter := slacker.NewRateLimiter(2, time.Minute, slacker.WithRateLimitClock(clock.Now))
+
+	if !limiter.Allow("U123") || !limiter.Allow("U123") {
+		t.Fatal("expected the bucket to start full")
+	}
+	if limiter.Allow("U123") {
+		t.Fatal("expected the bucket to be empty")
+	}
+	if !limiter.Allow("U456") {
+		t.Fatal("expected keys to be limited separately")
+	}
+
+	clock.Advance(29 * time.Second)
+	if limiter.Allow("U123") {
+		t.Fatal("expected no token before 30 seconds")
+	}
+
+	clock.Advance(time.Second)
+	if !limiter.Allow("U123") {
+		t.Fatal("expected a token after 30 seconds")
+	}
+
+	clock.Advance(time.Hour)
+	if !limiter.Allow("U123") || !limiter.Allow("U123") || limiter.Allow("U123") {
+		t.Fatal("expected the bucket to refill up to its capacity only")
+	}
+}
+
+func TestRateLimiterBurst(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	limiter := slacker.NewRateLimiter(1, time.Minute, slacker.WithRateLimitBurst(3), slacker.WithRateLimitClock(clock.Now))
+
+	for i := 0; i < 3; i++ {
+		if !limiter.Allow("U123") {
+			t.Fatalf("expected invocation %d to be allowed", i+1)
+		}
+	}
+	if limiter.Allow("U123") {
+		t.Fatal("expected the burst to be exhausted")
+	}
+}
+
+func TestRateLimiterCommandMiddleware(t *testing.T) {
+	harness := slackertest.NewHarness()
+	defer harness.Close()
+
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	limiter := slacker.NewRateLimiter(1, time.Minute, slacker.WithRateLimitMessage("slow down"), slacker.WithRateLimitClock(clock.Now))
+
+	runs := 0
+	harness.Bot().AddCommand(&slacker.CommandDefinition{
+		Command:     "ping",
+		Middlewares: []slacker.CommandMiddlewareHandler{limiter.CommandMiddleware()},
+		Handler: func(ctx *slacker.CommandContext) {
+			runs++
+		},
+	})
+
+	harness.SendMessage("C123", "U123", "ping")
+	harness.SendMessage("C123", "U123", "ping")
+
+	if runs != 1 {
+		t.Fatalf("expected 1 run, got %d", runs)
+	}
+
+	messages := harness.Messages()
+	if len(messages) != 1 || messages[0].Text != "slow down" || messages[0].EphemeralUserID != "U123" {
+		t.Fatalf("expected an ephemeral rate limit reply, got %+v", messages)
+	}
+
+	clock.Advance(time.Minute)
+	harness.SendMessage("C123", "U123", "ping")
+
+	if runs != 2 {
+		t.Errorf("expected the command to run once refilled, got %d runs", runs)
+	}
+}
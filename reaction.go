@@ -0,0 +1,187 @@
+package slacker
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/slack-go/slack"
+	"github.com/slack-go/slack/slackevents"
+)
+
+const (
+	skinToneSeparator = "::"
+	emojiFormat       = ":%s:"
+	spanFetchMessage  = "slacker.lookup.message"
+	messageNotFound   = "message not found"
+)
+
+// ReactionDefinition structure contains definition of the bot reaction
+type ReactionDefinition struct {
+	// Emoji is the name of the reaction, with or without colons. For instance, `ticket`.
+	// Skin tone variants match the base emoji.
+	Emoji string
+
+	// Channels restricts the reaction to messages of these channel IDs, any channel if empty
+	Channels []string
+
+	Description string
+	Middlewares []ReactionMiddlewareHandler
+	Handler     ReactionHandler
+
+	// HideHelp will hide this reaction definition from appearing in the `help` results.
+	HideHelp bool
+}
+
+// newReaction creates a new bot reaction object
+func newReaction(definition *ReactionDefinition) *Reaction {
+	return &Reaction{
+		definition: definition,
+		emoji:      normalizeEmoji(definition.Emoji),
+	}
+}
+
+// Reaction structure contains the bot's reaction, description and handler
+type Reaction struct {
+	definition *ReactionDefinition
+	emoji      string
+}
+
+// Definition returns the reaction definition
+func (c *Reaction) Definition() *ReactionDefinition {
+	return c.definition
+}
+
+// Match determines whether the reaction should be triggered by the event
+func (c *Reaction) Match(event *slackevents.ReactionAddedEvent) bool {
+	if normalizeEmoji(event.Reaction) != c.emoji {
+		return false
+	}
+
+	if len(c.definition.Channels) == 0 {
+		return true
+	}
+
+	for _, channelID := range c.definition.Channels {
+		if channelID == event.Item.Channel {
+			return true
+		}
+	}
+	return false
+}
+
+// ReactionMessage contains the message a reaction was added to
+type ReactionMessage struct {
+	ChannelID       string
+	UserID          string
+	BotID           string
+	Text            string
+	TimeStamp       string
+	ThreadTimeStamp string
+	Permalink       string
+
+	// Data is the message as returned by the Slack API
+	Data *slack.Message
+}
+
+// InThread indicates if the message is a reply inside a thread
+func (m *ReactionMessage) InThread() bool {
+	return isMessageInThread(m.ThreadTimeStamp, m.TimeStamp)
+}
+
+// threadTimeStamp returns the timestamp of the thread replies go to
+func (m *ReactionMessage) threadTimeStamp() string {
+	if len(m.ThreadTimeStamp) > 0 {
+		return m.ThreadTimeStamp
+	}
+	return m.TimeStamp
+}
+
+// getReactionMessage fetches the message the reaction was added to, along with its permalink
+func getReactionMessage(ctx context.Context, logger StructuredLogger, slackClient *slack.Client, channelID string, timeStamp string) (*ReactionMessage, error) {
+	ctx, span := StartSpan(ctx, spanFetchMessage, Attr(logKeyChannelID, channelID))
+	defer span.End()
+
+	message, err := getMessage(ctx, slackClient, channelID, timeStamp)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	permalink, err := slackClient.GetPermalinkContext(ctx, &slack.PermalinkParameters{Channel: channelID, Ts: timeStamp})
+	if err != nil {
+		logger.Error("unable to get permalink", logKeyChannelID, channelID, logKeyError, err)
+	}
+
+	return &ReactionMessage{
+		ChannelID:       channelID,
+		UserID:          message.User,
+		BotID:           message.BotID,
+		Text:            message.Text,
+		TimeStamp:       message.Timestamp,
+		ThreadTimeStamp: message.ThreadTimestamp,
+		Permalink:       permalink,
+		Data:            message,
+	}, nil
+}
+
+// getMessage fetches a message from the channel's history, or from its thread for replies
+func getMessage(ctx context.Context, slackClient *slack.Client, channelID string, timeStamp string) (*slack.Message, error) {
+	history, err := slackClient.GetConversationHistoryContext(ctx, &slack.GetConversationHistoryParameters{
+		ChannelID: channelID,
+		Latest:    timeStamp,
+		Oldest:    timeStamp,
+		Inclusive: true,
+		Limit:     1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range history.Messages {
+		if history.Messages[i].Timestamp == timeStamp {
+			return &history.Messages[i], nil
+		}
+	}
+
+	// The message is a reply, which only its thread lists. Look up the thread
+	// first, then the reply within it.
+	thread, _, _, err := slackClient.GetConversationRepliesContext(ctx, &slack.GetConversationRepliesParameters{
+		ChannelID: channelID,
+		Timestamp: timeStamp,
+		Limit:     1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(thread) == 0 || len(thread[0].ThreadTimestamp) == 0 {
+		return nil, errors.New(messageNotFound)
+	}
+
+	replies, _, _, err := slackClient.GetConversationRepliesContext(ctx, &slack.GetConversationRepliesParameters{
+		ChannelID: channelID,
+		Timestamp: thread[0].ThreadTimestamp,
+		Latest:    timeStamp,
+		Oldest:    timeStamp,
+		Inclusive: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range replies {
+		if replies[i].Timestamp == timeStamp {
+			return &replies[i], nil
+		}
+	}
+	return nil, errors.New(messageNotFound)
+}
+
+func normalizeEmoji(emoji string) string {
+	emoji = strings.Trim(emoji, ":")
+	if index := strings.Index(emoji, skinToneSeparator); index >= 0 {
+		emoji = emoji[:index]
+	}
+	return emoji
+}
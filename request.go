@@ -9,13 +9,14 @@ const (
 )
 
 // newRequest creates a new Request structure
-func newRequest(properties *proper.Properties) *Request {
-	return &Request{properties: properties}
+func newRequest(properties *proper.Properties, definitions []*ParameterDefinition) *Request {
+	return &Request{properties: properties, definitions: definitions}
 }
 
 // Request contains the Event received and parameters
 type Request struct {
-	properties *proper.Properties
+	properties  *proper.Properties
+	definitions []*ParameterDefinition
 }
 
 // Param attempts to look up a string value by key. If not found, return the an empty string
@@ -43,6 +44,16 @@ func (r *Request) FloatParam(key string, defaultValue float64) float64 {
 	return r.properties.FloatParam(key, defaultValue)
 }
 
+// Bind sets the fields of the struct pointed to by out from the request's parameters.
+// Fields are matched using the `slacker` tag, for instance `slacker:"count"`, and
+// converted using the command's parameter definitions when declared, or otherwise
+// based on the field's type. Strings holding user or channel mentions such as
+// `<@U123>` or `<#C123|general>` are converted into their IDs.
+// Parameters that were not provided leave their fields untouched.
+func (r *Request) Bind(out any) error {
+	return bindProperties(r.properties, r.definitions, out)
+}
+
 // Properties returns the properties of the request
 func (r *Request) Properties() *proper.Properties {
 	return r.properties
@@ -30,6 +30,12 @@ func (r *ResponseReplier) ReplyBlocks(blocks []slack.Block, options ...ReplyOpti
 	return r.replier.ReplyBlocks(blocks, options...)
 }
 
+// DeleteOriginal deletes the message an interaction came from, or the last
+// response of a slash command. It fails for any other event.
+func (r *ResponseReplier) DeleteOriginal() error {
+	return r.replier.DeleteOriginal()
+}
+
 // Post send a message to a channel
 func (r *ResponseReplier) Post(channel string, message string, options ...PostOption) (string, error) {
 	return r.writer.Post(channel, message, options...)
@@ -1,21 +1,42 @@
 package slacker
 
 import (
+	"errors"
+
 	"github.com/slack-go/slack"
 )
 
+const (
+	noOriginalMessage = "no original message to delete, only interactions and slash commands have one"
+)
+
 // newReplier creates a new replier structure
 func newReplier(channelID string, userID string, inThread bool, eventTS string, writer *Writer) *Replier {
 	return &Replier{channelID: channelID, userID: userID, inThread: inThread, eventTS: eventTS, writer: writer}
 }
 
+// newInteractionReplier creates a new replier structure able to delete the
+// message the interaction came from, if any
+func newInteractionReplier(channelID string, userID string, inThread bool, messageTS string, writer *Writer) *Replier {
+	return &Replier{channelID: channelID, userID: userID, inThread: inThread, eventTS: messageTS, originalTS: messageTS, writer: writer}
+}
+
+// newResponseURLReplier creates a new replier structure responding through the
+// response URL, once the request was acknowledged
+func newResponseURLReplier(channelID string, userID string, responseURL string, acknowledger *acknowledger, writer *Writer) *Replier {
+	return &Replier{channelID: channelID, userID: userID, responseURL: responseURL, acknowledger: acknowledger, writer: writer}
+}
+
 // Replier sends messages to the same channel the event came from
 type Replier struct {
-	channelID string
-	userID    string
-	inThread  bool
-	eventTS   string
-	writer    *Writer
+	channelID    string
+	userID       string
+	inThread     bool
+	eventTS      string
+	originalTS   string
+	responseURL  string
+	acknowledger *acknowledger
+	writer       *Writer
 }
 
 // Reply send a message to the current channel
@@ -36,12 +57,49 @@ func (r *Replier) ReplyBlocks(blocks []slack.Block, options ...ReplyOption) (str
 	return r.writer.PostBlocks(r.channelID, blocks, responseOptions...)
 }
 
+// DeleteOriginal deletes the message an interaction came from, or the last
+// response of a slash command sent through its response URL. It fails for
+// any other event, such as messages and view submissions.
+func (r *Replier) DeleteOriginal() error {
+	if len(r.responseURL) > 0 {
+		r.acknowledger.ack(nil)
+		return r.writer.DeleteResponse(r.responseURL)
+	}
+
+	if len(r.originalTS) == 0 {
+		return errors.New(noOriginalMessage)
+	}
+
+	_, err := r.writer.Delete(r.channelID, r.originalTS)
+	return err
+}
+
 func (r *Replier) convertOptions(options ...ReplyOption) []PostOption {
 	replyOptions := newReplyOptions(options...)
 	responseOptions := []PostOption{
 		SetAttachments(replyOptions.Attachments),
 	}
 
+	// Respond through the response URL, unless the reply needs the chat API
+	if len(r.responseURL) > 0 && len(replyOptions.ReplaceMessageTS) == 0 && replyOptions.ScheduleTime == nil {
+		// Responses sent before the acknowledgement would show up before it
+		r.acknowledger.ack(nil)
+
+		responseOptions = append(responseOptions, SetResponseURL(r.responseURL))
+		if replyOptions.ReplaceOriginal {
+			responseOptions = append(responseOptions, SetReplaceOriginal())
+		}
+
+		if replyOptions.IsEphemeral {
+			responseOptions = append(responseOptions, SetEphemeral(r.userID))
+		}
+		return responseOptions
+	}
+
+	if replyOptions.ReplaceOriginal && len(r.eventTS) > 0 {
+		responseOptions = append(responseOptions, SetReplace(r.eventTS))
+	}
+
 	// If the original message came from a thread, reply in a thread, unless there is an override
 	if (replyOptions.InThread == nil && r.inThread) || (replyOptions.InThread != nil && *replyOptions.InThread) {
 		responseOptions = append(responseOptions, SetThreadTS(r.eventTS))
@@ -0,0 +1,86 @@
+package slacker_test
+
+import (
+	"testing"
+
+	"github.com/shomali11/slacker/v2"
+	"github.com/shomali11/slacker/v2/slackertest"
+	"github.com/slack-go/slack"
+)
+
+func TestDeleteOriginalFromInteraction(t *testing.T) {
+	harness := slackertest.NewHarness()
+	defer harness.Close()
+
+	var err error
+	harness.Bot().AddInteraction(&slacker.InteractionDefinition{
+		Type:     slack.InteractionTypeBlockActions,
+		ActionID: "dismiss",
+		Handler: func(ctx *slacker.InteractionContext) {
+			err = ctx.Response().DeleteOriginal()
+		},
+	})
+
+	callback := newBlockActionsCallback(&slack.BlockAction{ActionID: "dismiss"})
+	callback.MessageTs = "1234.5678"
+	harness.SendInteraction(callback)
+
+	messages := harness.Messages()
+	if err != nil || len(messages) != 1 || !messages[0].IsDelete() || messages[0].TimeStamp != "1234.5678" {
+		t.Errorf("expected the message of the interaction to be deleted, got %v %+v", err, messages)
+	}
+}
+
+func TestDeleteOriginalFromSlashCommand(t *testing.T) {
+	harness := slackertest.NewHarness()
+	defer harness.Close()
+
+	var err error
+	harness.Bot().AddCommand(&slacker.CommandDefinition{
+		Command: "dismiss",
+		Handler: func(ctx *slacker.CommandContext) {
+			err = ctx.Response().DeleteOriginal()
+		},
+	})
+
+	harness.SendSlashCommand("C123", "U123", "/dismiss", "")
+
+	messages := harness.Messages()
+	if err != nil || len(messages) != 1 || !messages[0].DeleteOriginal {
+		t.Errorf("expected the last response to be deleted through the response URL, got %v %+v", err, messages)
+	}
+}
+
+func TestDeleteOriginalWithoutOriginal(t *testing.T) {
+	harness := slackertest.NewHarness()
+	defer harness.Close()
+
+	var messageErr, submissionErr error
+	harness.Bot().AddCommand(&slacker.CommandDefinition{
+		Command: "dismiss",
+		Handler: func(ctx *slacker.CommandContext) {
+			messageErr = ctx.Response().DeleteOriginal()
+		},
+	})
+	harness.Bot().AddInteraction(&slacker.InteractionDefinition{
+		Type:          slack.InteractionTypeViewSubmission,
+		InteractionID: "feedback",
+		Handler: func(ctx *slacker.InteractionContext) {
+			submissionErr = ctx.Response().DeleteOriginal()
+		},
+	})
+
+	harness.SendMessage("C123", "U123", "dismiss")
+
+	callback := &slack.InteractionCallback{Type: slack.InteractionTypeViewSubmission}
+	callback.User.ID = "U123"
+	callback.View.CallbackID = "feedback"
+	harness.SubmitInteraction(callback)
+
+	if messageErr == nil || submissionErr == nil {
+		t.Errorf("expected errors deleting the original of a message and a view submission, got %v %v", messageErr, submissionErr)
+	}
+	if messages := harness.Messages(); len(messages) != 0 {
+		t.Errorf("expected nothing deleted, got %+v", messages)
+	}
+}
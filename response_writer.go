@@ -8,15 +8,17 @@ import (
 )
 
 // newWriter creates a new poster structure
-func newWriter(ctx context.Context, logger Logger, slackClient *slack.Client) *Writer {
-	return &Writer{ctx: ctx, logger: logger, slackClient: slackClient}
+func newWriter(ctx context.Context, logger StructuredLogger, metrics *metrics, slackClient *slack.Client) *Writer {
+	return &Writer{ctx: ctx, logger: logger, metrics: metrics, slackClient: slackClient}
 }
 
 // Writer sends messages to Slack
 type Writer struct {
 	ctx         context.Context
-	logger      Logger
+	logger      StructuredLogger
+	metrics     *metrics
 	slackClient *slack.Client
+	functions   *functionsClient
 }
 
 // Post send a message to a channel
@@ -31,7 +33,7 @@ func (r *Writer) PostError(channel string, err error, options ...PostOption) (st
 		Color: "danger",
 		Text:  err.Error(),
 	})
-	return r.post(channel, "", []slack.Block{}, SetAttachments(attachments))
+	return r.post(channel, "", []slack.Block{}, append(options, SetAttachments(attachments))...)
 }
 
 // PostBlocks send blocks to a channel
@@ -41,16 +43,73 @@ func (r *Writer) PostBlocks(channel string, blocks []slack.Block, options ...Pos
 
 // Delete deletes message
 func (r *Writer) Delete(channel string, messageTimestamp string) (string, error) {
-	_, timestamp, err := r.slackClient.DeleteMessage(
+	ctx, span := StartSpan(r.ctx, spanDeleteMessage, Attr(logKeyChannelID, channel))
+	defer span.End()
+
+	_, timestamp, err := r.slackClient.DeleteMessageContext(
+		ctx,
 		channel,
 		messageTimestamp,
 	)
 	if err != nil {
-		r.logger.Errorf("failed to delete message: %v\n", err)
+		span.RecordError(err)
+		r.logger.Error("failed to delete message", logKeyError, err)
+		r.metrics.writerFailed(operationDelete)
 	}
 	return timestamp, err
 }
 
+// DeleteResponse deletes the last message sent through the response URL
+func (r *Writer) DeleteResponse(responseURL string) error {
+	ctx, span := StartSpan(r.ctx, spanDeleteMessage)
+	defer span.End()
+
+	_, _, _, err := r.slackClient.SendMessageContext(ctx, "", slack.MsgOptionDeleteOriginal(responseURL))
+	if err != nil {
+		span.RecordError(err)
+		r.logger.Error("failed to delete response", logKeyError, err)
+		r.metrics.writerFailed(operationDelete)
+	}
+	return err
+}
+
+// CompleteFunction completes a run of a Workflow Builder custom step with its outputs
+func (r *Writer) CompleteFunction(functionExecutionID string, outputs map[string]any) error {
+	if outputs == nil {
+		outputs = map[string]any{}
+	}
+
+	return r.callFunctions(methodCompleteSuccess, map[string]any{
+		"function_execution_id": functionExecutionID,
+		"outputs":               outputs,
+	})
+}
+
+// FailFunction fails a run of a Workflow Builder custom step with the message
+func (r *Writer) FailFunction(functionExecutionID string, message string) error {
+	return r.callFunctions(methodCompleteError, map[string]any{
+		"function_execution_id": functionExecutionID,
+		"error":                 message,
+	})
+}
+
+func (r *Writer) callFunctions(method string, request map[string]any) error {
+	if r.functions == nil {
+		return errFunctionsNotSupported
+	}
+
+	ctx, span := StartSpan(r.ctx, spanSlackPrefix+method)
+	defer span.End()
+
+	err := r.functions.call(ctx, method, request)
+	if err != nil {
+		span.RecordError(err)
+		r.logger.Error("failed to complete function", logKeyError, err)
+		r.metrics.writerFailed(operationCompleteFunction)
+	}
+	return err
+}
+
 func (r *Writer) post(channel string, message string, blocks []slack.Block, options ...PostOption) (string, error) {
 	postOptions := newPostOptions(options...)
 
@@ -60,6 +119,10 @@ func (r *Writer) post(channel string, message string, blocks []slack.Block, opti
 		slack.MsgOptionBlocks(blocks...),
 	}
 
+	if len(postOptions.ResponseURL) > 0 {
+		return r.respond(channel, postOptions, opts...)
+	}
+
 	if len(postOptions.ThreadTS) > 0 {
 		opts = append(opts, slack.MsgOptionTS(postOptions.ThreadTS))
 	}
@@ -77,13 +140,42 @@ func (r *Writer) post(channel string, message string, blocks []slack.Block, opti
 		opts = append(opts, slack.MsgOptionSchedule(postAt))
 	}
 
+	ctx, span := StartSpan(r.ctx, spanPostMessage, Attr(logKeyChannelID, channel))
+	defer span.End()
+
 	_, timestamp, err := r.slackClient.PostMessageContext(
-		r.ctx,
+		ctx,
 		channel,
 		opts...,
 	)
 	if err != nil {
-		r.logger.Errorf("failed to post message: %v\n", err)
+		span.RecordError(err)
+		r.logger.Error("failed to post message", logKeyError, err)
+		r.metrics.writerFailed(operationPost)
+	}
+	return timestamp, err
+}
+
+// respond sends the message through the response URL, which returns no timestamp
+func (r *Writer) respond(channel string, postOptions *postOptions, opts ...slack.MsgOption) (string, error) {
+	responseType := slack.ResponseTypeInChannel
+	if len(postOptions.EphemeralUserID) > 0 {
+		responseType = slack.ResponseTypeEphemeral
+	}
+
+	opts = append(opts, slack.MsgOptionResponseURL(postOptions.ResponseURL, responseType))
+	if postOptions.ReplaceOriginal {
+		opts = append(opts, slack.MsgOptionReplaceOriginal(postOptions.ResponseURL))
+	}
+
+	ctx, span := StartSpan(r.ctx, spanPostMessage, Attr(logKeyChannelID, channel))
+	defer span.End()
+
+	_, timestamp, _, err := r.slackClient.SendMessageContext(ctx, channel, opts...)
+	if err != nil {
+		span.RecordError(err)
+		r.logger.Error("failed to post response", logKeyError, err)
+		r.metrics.writerFailed(operationPost)
 	}
 	return timestamp, err
 }
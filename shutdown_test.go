@@ -0,0 +1,179 @@
+package slacker_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/shomali11/slacker/v2"
+	"github.com/shomali11/slacker/v2/slackertest"
+)
+
+// recordingLogger records the messages logged at the error level
+type recordingLogger struct {
+	mutex  sync.Mutex
+	errors []string
+}
+
+func (l *recordingLogger) Debug(string, ...any) {}
+
+func (l *recordingLogger) Info(string, ...any) {}
+
+func (l *recordingLogger) Warn(string, ...any) {}
+
+func (l *recordingLogger) Error(msg string, _ ...any) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.errors = append(l.errors, msg)
+}
+
+func (l *recordingLogger) With(...any) slacker.StructuredLogger {
+	return l
+}
+
+func (l *recordingLogger) count(prefix string) int {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	count := 0
+	for _, msg := range l.errors {
+		if strings.HasPrefix(msg, prefix) {
+			count++
+		}
+	}
+	return count
+}
+
+// listen starts the bot over HTTP at the address and waits for it to serve
+// requests. It returns a function stopping the bot and waiting for Listen to return.
+func listen(t *testing.T, bot *slacker.Slacker, address string) func() {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- bot.Listen(ctx)
+	}()
+
+	for deadline := time.Now().Add(5 * time.Second); ; {
+		conn, err := net.Dial("tcp", address)
+		if err == nil {
+			conn.Close()
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the bot to listen, got %v", err)
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	return func() {
+		cancel()
+		select {
+		case err := <-done:
+			if err != nil {
+				t.Errorf("unexpected error %v", err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("expected Listen to return")
+		}
+	}
+}
+
+// freeAddress returns a local address nothing listens on
+func freeAddress(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	defer listener.Close()
+
+	return listener.Addr().String()
+}
+
+func newShutdownHarness(address string, logger slacker.StructuredLogger, timeout time.Duration) *slackertest.Harness {
+	return slackertest.NewHarness(
+		slacker.WithTransportMode(slacker.TransportModeHTTP),
+		slacker.WithHTTPAddress(address),
+		slacker.WithSigningSecret("secret"),
+		slacker.WithStructuredLogger(logger),
+		slacker.WithShutdownTimeout(timeout),
+	)
+}
+
+func TestShutdownDrainsInFlightHandlers(t *testing.T) {
+	logger := &recordingLogger{}
+	address := freeAddress(t)
+	harness := newShutdownHarness(address, logger, 5*time.Second)
+	defer harness.Close()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var finished bool
+	var handlerErr error
+	harness.Bot().AddJob(&slacker.JobDefinition{
+		Name:           "report",
+		CronExpression: "0 0 1 1 *",
+		Handler: func(ctx *slacker.JobContext) {
+			close(started)
+			<-release
+			handlerErr = ctx.Context().Err()
+			finished = true
+		},
+	})
+
+	stop := listen(t, harness.Bot(), address)
+	harness.Bot().JobManager().Run("report")
+	<-started
+
+	// Released while shutting down, Listen returns only once the handler completed
+	go close(release)
+	stop()
+
+	if !finished || handlerErr != nil {
+		t.Errorf("expected the handler to complete before shutdown with its context alive, got %t %v", finished, handlerErr)
+	}
+	if count := logger.count("abandoned"); count != 0 {
+		t.Errorf("expected nothing abandoned, got %d reports", count)
+	}
+}
+
+func TestShutdownCancelsHandlersPastDeadline(t *testing.T) {
+	logger := &recordingLogger{}
+	address := freeAddress(t)
+	harness := newShutdownHarness(address, logger, 10*time.Millisecond)
+	defer harness.Close()
+
+	started := make(chan struct{})
+	canceled := make(chan error, 1)
+	harness.Bot().AddJob(&slacker.JobDefinition{
+		Name:           "stuck",
+		CronExpression: "0 0 1 1 *",
+		Handler: func(ctx *slacker.JobContext) {
+			close(started)
+			<-ctx.Context().Done()
+			canceled <- ctx.Context().Err()
+		},
+	})
+
+	stop := listen(t, harness.Bot(), address)
+	harness.Bot().JobManager().Run("stuck")
+	<-started
+	stop()
+
+	select {
+	case err := <-canceled:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected the handler context to be canceled, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the handler context to be canceled past the deadline")
+	}
+
+	if count := logger.count("abandoned"); count != 1 {
+		t.Errorf("expected the abandoned handler to be reported once, got %d reports", count)
+	}
+}
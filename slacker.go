@@ -4,8 +4,11 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/robfig/cron/v3"
+	"github.com/shomali11/proper"
 	"github.com/slack-go/slack"
 	"github.com/slack-go/slack/slackevents"
 	"github.com/slack-go/slack/socketmode"
@@ -21,6 +24,7 @@ const (
 	boldMessageFormat    = "*%s*"
 	italicMessageFormat  = "_%s_"
 	exampleMessageFormat = "_*Example:*_ %s"
+	usageMessageFormat   = "_*Usage:*_ %s"
 )
 
 // NewClient creates a new client using the Slack API
@@ -35,42 +39,89 @@ func NewClient(botToken, appToken string, clientOptions ...ClientOption) *Slacke
 	)
 
 	slacker := &Slacker{
-		slackClient:              slackAPI,
-		socketModeClient:         socketModeClient,
-		cronClient:               cron.New(cron.WithLocation(options.CronLocation)),
-		commandGroups:            []*CommandGroup{newGroup("")},
-		botInteractionMode:       options.BotMode,
-		sanitizeEventTextHandler: defaultEventTextSanitizer,
-		logger:                   options.Logger,
-		interactions:             make(map[slack.InteractionType][]*Interaction),
+		slackClient:                    slackAPI,
+		socketModeClient:               socketModeClient,
+		transportMode:                  options.TransportMode,
+		signingSecret:                  options.SigningSecret,
+		httpAddress:                    options.HTTPAddress,
+		commandGroups:                  []*CommandGroup{newGroup("")},
+		botInteractionMode:             options.BotMode,
+		sanitizeEventTextHandler:       defaultEventTextSanitizer,
+		logger:                         options.StructuredLogger,
+		roleProvider:                   options.RoleProvider,
+		cache:                          options.Cache,
+		lazyLookups:                    options.LazyLookups,
+		dispatcher:                     newDispatcher(options.StructuredLogger, newClientWorkerPool(options)),
+		busyMessage:                    options.BusyMessage,
+		metrics:                        newMetrics(options.MetricsRecorder),
+		tracer:                         options.Tracer,
+		errorReporter:                  newErrorReporter(options.StructuredLogger, options.ErrorReplies),
+		shutdownTimeout:                options.ShutdownTimeout,
+		unauthorizedCommandHandler:     defaultUnauthorizedCommandHandler,
+		unauthorizedInteractionHandler: defaultUnauthorizedInteractionHandler,
+		interactions:                   make(map[slack.InteractionType][]*Interaction),
+		events:                         make(map[slackevents.EventsAPIType][]*Event),
+		store:                          options.Store,
+		conversations:                  newConversationManager(options.ConversationStore),
+		functions:                      make(map[string]*Function),
+		functionsClient:                newFunctionsClient(options.APIURL, botToken),
 	}
+	slacker.jobManager = newJobManager(cron.New(cron.WithLocation(options.CronLocation)), options.StructuredLogger, slacker.runJob)
 	return slacker
 }
 
 // Slacker contains the Slack API, botCommands, and handlers
 type Slacker struct {
-	slackClient                   *slack.Client
-	socketModeClient              *socketmode.Client
-	cronClient                    *cron.Cron
-	commandMiddlewares            []CommandMiddlewareHandler
-	commandGroups                 []*CommandGroup
-	interactionMiddlewares        []InteractionMiddlewareHandler
-	interactions                  map[slack.InteractionType][]*Interaction
-	jobMiddlewares                []JobMiddlewareHandler
-	jobs                          []*Job
-	onHello                       func(socketmode.Event)
-	onConnected                   func(socketmode.Event)
-	onConnecting                  func(socketmode.Event)
-	onConnectionError             func(socketmode.Event)
-	onDisconnected                func(socketmode.Event)
-	unsupportedInteractionHandler InteractionHandler
-	helpDefinition                *CommandDefinition
-	unsupportedCommandHandler     CommandHandler
-	unsupportedEventHandler       func(socketmode.Event)
-	appID                         string
-	botInteractionMode            BotMode
-	sanitizeEventTextHandler      func(string) string
-	logger                        Logger
+	slackClient                    *slack.Client
+	socketModeClient               *socketmode.Client
+	transportMode                  TransportMode
+	signingSecret                  string
+	httpAddress                    string
+	commandMiddlewares             []CommandMiddlewareHandler
+	commandGroups                  []*CommandGroup
+	interactionMiddlewares         []InteractionMiddlewareHandler
+	interactions                   map[slack.InteractionType][]*Interaction
+	jobMiddlewares                 []JobMiddlewareHandler
+	jobManager                     *JobManager
+	events                         map[slackevents.EventsAPIType][]*Event
+	eventMiddlewares               []EventMiddlewareHandler
+	reactions                      []*Reaction
+	reactionMiddlewares            []ReactionMiddlewareHandler
+	appHome                        *AppHomeDefinition
+	store                          Store
+	conversations                  *conversationManager
+	conversationMiddlewares        []ConversationMiddlewareHandler
+	options                        []*Options
+	optionsMiddlewares             []OptionsMiddlewareHandler
+	functions                      map[string]*Function
+	functionMiddlewares            []FunctionMiddlewareHandler
+	functionsClient                *functionsClient
+	onHello                        func(socketmode.Event)
+	onConnected                    func(socketmode.Event)
+	onConnecting                   func(socketmode.Event)
+	onConnectionError              func(socketmode.Event)
+	onDisconnected                 func(socketmode.Event)
+	unsupportedInteractionHandler  InteractionHandler
+	unauthorizedInteractionHandler InteractionHandler
+	helpDefinition                 *CommandDefinition
+	helpOnce                       sync.Once
+	unsupportedCommandHandler      CommandHandler
+	unauthorizedCommandHandler     CommandHandler
+	unsupportedEventHandler        func(socketmode.Event)
+	appID                          string
+	appIDOnce                      sync.Once
+	botInteractionMode             BotMode
+	sanitizeEventTextHandler       func(string) string
+	logger                         StructuredLogger
+	roleProvider                   RoleProvider
+	cache                          Cache
+	lazyLookups                    bool
+	dispatcher                     *dispatcher
+	busyMessage                    string
+	metrics                        *metrics
+	tracer                         Tracer
+	errorReporter                  *errorReporter
+	shutdownTimeout                time.Duration
 }
 
 // GetCommandGroups returns Command Groups
@@ -85,7 +136,38 @@ func (s *Slacker) GetInteractions() map[slack.InteractionType][]*Interaction {
 
 // GetJobs returns Jobs
 func (s *Slacker) GetJobs() []*Job {
-	return s.jobs
+	return s.jobManager.getJobs()
+}
+
+// JobManager returns the manager of the bot's jobs, to add, remove, pause,
+// resume or trigger jobs while the bot listens
+func (s *Slacker) JobManager() *JobManager {
+	return s.jobManager
+}
+
+// GetReactions returns Reactions
+func (s *Slacker) GetReactions() []*Reaction {
+	return s.reactions
+}
+
+// GetOptions returns the options of external selects
+func (s *Slacker) GetOptions() []*Options {
+	return s.options
+}
+
+// GetFunctions returns the Workflow Builder custom steps, keyed by callback ID
+func (s *Slacker) GetFunctions() map[string]*Function {
+	return s.functions
+}
+
+// GetEvents returns Event handlers
+func (s *Slacker) GetEvents() map[slackevents.EventsAPIType][]*Event {
+	return s.events
+}
+
+// GetConversations returns Conversations
+func (s *Slacker) GetConversations() map[string]*Conversation {
+	return s.conversations.conversations
 }
 
 // SlackClient returns the internal slack.Client of Slacker struct
@@ -93,6 +175,11 @@ func (s *Slacker) SlackClient() *slack.Client {
 	return s.slackClient
 }
 
+// Store returns the store shared by handlers, not namespaced
+func (s *Slacker) Store() Store {
+	return s.store
+}
+
 // SocketModeClient returns the internal socketmode.Client of Slacker struct
 func (s *Slacker) SocketModeClient() *socketmode.Client {
 	return s.socketModeClient
@@ -133,11 +220,26 @@ func (s *Slacker) UnsupportedCommandHandler(unsupportedCommandHandler CommandHan
 	s.unsupportedCommandHandler = unsupportedCommandHandler
 }
 
+// UnauthorizedInteractionHandler handles interactions when the user does not hold the required roles
+func (s *Slacker) UnauthorizedInteractionHandler(unauthorizedInteractionHandler InteractionHandler) {
+	s.unauthorizedInteractionHandler = unauthorizedInteractionHandler
+}
+
+// UnauthorizedCommandHandler handles messages when the user does not hold the required roles
+func (s *Slacker) UnauthorizedCommandHandler(unauthorizedCommandHandler CommandHandler) {
+	s.unauthorizedCommandHandler = unauthorizedCommandHandler
+}
+
 // UnsupportedEventHandler handles events when an unknown event is seen
 func (s *Slacker) UnsupportedEventHandler(unsupportedEventHandler func(socketmode.Event)) {
 	s.unsupportedEventHandler = unsupportedEventHandler
 }
 
+// OnError handles errors returned, and panics raised, by handlers of any kind
+func (s *Slacker) OnError(onError ErrorHandler) {
+	s.errorReporter.onError = onError
+}
+
 // SanitizeEventTextHandler overrides the default event text sanitization
 func (s *Slacker) SanitizeEventTextHandler(sanitizeEventTextHandler func(in string) string) {
 	s.sanitizeEventTextHandler = sanitizeEventTextHandler
@@ -175,15 +277,21 @@ func (s *Slacker) AddCommandGroup(prefix string) *CommandGroup {
 
 // AddInteraction define a new interaction and append it to the list of interactions
 func (s *Slacker) AddInteraction(definition *InteractionDefinition) {
-	if len(definition.InteractionID) == 0 {
+	if len(definition.Type) == 0 {
+		s.logger.Error("missing `Type`")
+		return
+	}
+	if len(definition.InteractionID) == 0 && (definition.Type != slack.InteractionTypeBlockActions || len(definition.ActionID) == 0) {
 		s.logger.Error("missing `ID`")
 		return
 	}
-	if len(definition.Type) == 0 {
-		s.logger.Error("missing `Type`")
+
+	interaction, err := newInteraction(definition)
+	if err != nil {
+		s.logger.Error("invalid interaction pattern", logKeyInteraction, definition.name(), logKeyError, err)
 		return
 	}
-	s.interactions[definition.Type] = append(s.interactions[definition.Type], newInteraction(definition))
+	s.interactions[definition.Type] = append(s.interactions[definition.Type], interaction)
 }
 
 // AddInteractionMiddleware appends a new interaction middleware to the list of root level interaction middlewares
@@ -191,13 +299,107 @@ func (s *Slacker) AddInteractionMiddleware(middleware InteractionMiddlewareHandl
 	s.interactionMiddlewares = append(s.interactionMiddlewares, middleware)
 }
 
+// AddReaction define a new reaction and append it to the list of reactions
+func (s *Slacker) AddReaction(definition *ReactionDefinition) {
+	if len(normalizeEmoji(definition.Emoji)) == 0 {
+		s.logger.Error("missing `Emoji`")
+		return
+	}
+	s.reactions = append(s.reactions, newReaction(definition))
+}
+
+// AddReactionMiddleware appends a new reaction middleware to the list of root level reaction middlewares
+func (s *Slacker) AddReactionMiddleware(middleware ReactionMiddlewareHandler) {
+	s.reactionMiddlewares = append(s.reactionMiddlewares, middleware)
+}
+
+// AddEvent define a new event handler and append it to the list of the event type's handlers
+func (s *Slacker) AddEvent(definition *EventDefinition) {
+	if len(definition.Type) == 0 {
+		s.logger.Error("missing `Type`")
+		return
+	}
+	s.events[definition.Type] = append(s.events[definition.Type], newEvent(definition))
+}
+
+// AddEventMiddleware appends a new event middleware to the list of root level event middlewares
+func (s *Slacker) AddEventMiddleware(middleware EventMiddlewareHandler) {
+	s.eventMiddlewares = append(s.eventMiddlewares, middleware)
+}
+
+// AddConversation define a new conversation, started from commands with CommandContext.StartConversation
+func (s *Slacker) AddConversation(definition *ConversationDefinition) {
+	if len(definition.Name) == 0 {
+		s.logger.Error("missing `Name`")
+		return
+	}
+
+	if len(definition.Steps) == 0 {
+		s.logger.Error("missing `Steps`")
+		return
+	}
+	s.conversations.conversations[definition.Name] = newConversation(definition)
+}
+
+// AddConversationMiddleware appends a new conversation middleware to the list of root level conversation middlewares
+func (s *Slacker) AddConversationMiddleware(middleware ConversationMiddlewareHandler) {
+	s.conversationMiddlewares = append(s.conversationMiddlewares, middleware)
+}
+
+// AddOptions define the options of external selects and append them to the list of options.
+// Block suggestions are handled by the first options matching them.
+func (s *Slacker) AddOptions(definition *OptionsDefinition) {
+	if len(definition.ActionID) == 0 {
+		s.logger.Error("missing `ActionID`")
+		return
+	}
+
+	options, err := newOptions(definition)
+	if err != nil {
+		s.logger.Error("invalid options pattern", logKeyAction, definition.ActionID, logKeyError, err)
+		return
+	}
+	s.options = append(s.options, options)
+}
+
+// AddOptionsMiddleware appends a new options middleware to the list of root level options middlewares
+func (s *Slacker) AddOptionsMiddleware(middleware OptionsMiddlewareHandler) {
+	s.optionsMiddlewares = append(s.optionsMiddlewares, middleware)
+}
+
+// AddFunction define a new Workflow Builder custom step, run on `function_executed` events
+func (s *Slacker) AddFunction(definition *FunctionDefinition) {
+	if len(definition.CallbackID) == 0 {
+		s.logger.Error("missing `CallbackID`")
+		return
+	}
+	s.functions[definition.CallbackID] = newFunction(definition)
+}
+
+// AddFunctionMiddleware appends a new function middleware to the list of root level function middlewares
+func (s *Slacker) AddFunctionMiddleware(middleware FunctionMiddlewareHandler) {
+	s.functionMiddlewares = append(s.functionMiddlewares, middleware)
+}
+
+// AppHome defines the handler rendering the App Home tab of each user
+func (s *Slacker) AppHome(definition *AppHomeDefinition) {
+	if definition.Handler == nil {
+		s.logger.Error("missing `Handler`")
+		return
+	}
+	s.appHome = definition
+}
+
 // AddJob define a new cron job and append it to the list of jobs
 func (s *Slacker) AddJob(definition *JobDefinition) {
 	if len(definition.CronExpression) == 0 {
 		s.logger.Error("missing `CronExpression`")
 		return
 	}
-	s.jobs = append(s.jobs, newJob(definition))
+
+	if err := s.jobManager.Add(definition); err != nil {
+		s.logger.Error("unable to schedule job", logKeyJob, definition.Name, logKeyError, err)
+	}
 }
 
 // AddJobMiddleware appends a new job middleware to the list of root level job middlewares
@@ -209,134 +411,69 @@ func (s *Slacker) AddJobMiddleware(middleware JobMiddlewareHandler) {
 func (s *Slacker) Listen(ctx context.Context) error {
 	s.prependHelpHandle()
 
-	go func() {
-		for {
-			select {
-			case <-ctx.Done():
-				return
-			case socketEvent, ok := <-s.socketModeClient.Events:
-				if !ok {
-					return
-				}
+	s.dispatcher.start(ctx)
+	s.jobManager.start()
+	defer s.shutdown()
 
-				switch socketEvent.Type {
-				case socketmode.EventTypeConnecting:
-					s.logger.Infof("connecting to Slack with Socket Mode...\n")
-
-					if s.onConnecting == nil {
-						continue
-					}
-					go s.onConnecting(socketEvent)
-
-				case socketmode.EventTypeConnectionError:
-					s.logger.Infof("connection failed. Retrying later...\n")
-
-					if s.onConnectionError == nil {
-						continue
-					}
-					go s.onConnectionError(socketEvent)
-
-				case socketmode.EventTypeConnected:
-					s.logger.Infof("connected to Slack with Socket Mode.\n")
-
-					if s.onConnected == nil {
-						continue
-					}
-					go s.onConnected(socketEvent)
-
-				case socketmode.EventTypeHello:
-					s.appID = socketEvent.Request.ConnectionInfo.AppID
-					s.logger.Infof("connected as App ID %v\n", s.appID)
-
-					if s.onHello == nil {
-						continue
-					}
-					go s.onHello(socketEvent)
-
-				case socketmode.EventTypeDisconnect:
-					s.logger.Infof("disconnected due to %v\n", socketEvent.Request.Reason)
-
-					if s.onDisconnected == nil {
-						continue
-					}
-					go s.onDisconnected(socketEvent)
-
-				case socketmode.EventTypeEventsAPI:
-					event, ok := socketEvent.Data.(slackevents.EventsAPIEvent)
-					if !ok {
-						s.logger.Debugf("ignored %+v\n", socketEvent)
-						continue
-					}
-
-					// Acknowledge receiving the request
-					s.socketModeClient.Ack(*socketEvent.Request)
-
-					if event.Type != slackevents.CallbackEvent {
-						if s.unsupportedEventHandler != nil {
-							s.unsupportedEventHandler(socketEvent)
-						} else {
-							s.logger.Debugf("unsupported event received %+v\n", socketEvent)
-						}
-						continue
-					}
-
-					switch event.InnerEvent.Type {
-					case "message", "app_mention": // message-based events
-						go s.handleMessageEvent(ctx, event.InnerEvent.Data)
-
-					default:
-						if s.unsupportedEventHandler != nil {
-							s.unsupportedEventHandler(socketEvent)
-						} else {
-							s.logger.Debugf("unsupported event received %+v\n", socketEvent)
-						}
-					}
-
-				case socketmode.EventTypeSlashCommand:
-					event, ok := socketEvent.Data.(slack.SlashCommand)
-					if !ok {
-						s.logger.Debugf("ignored %+v\n", socketEvent)
-						continue
-					}
-
-					// Acknowledge receiving the request
-					s.socketModeClient.Ack(*socketEvent.Request)
-
-					go s.handleMessageEvent(ctx, &event)
-
-				case socketmode.EventTypeInteractive:
-					callback, ok := socketEvent.Data.(slack.InteractionCallback)
-					if !ok {
-						s.logger.Debugf("ignored %+v\n", socketEvent)
-						continue
-					}
-
-					// Acknowledge receiving the request
-					s.socketModeClient.Ack(*socketEvent.Request)
-
-					go s.handleInteractionEvent(ctx, &callback)
-
-				default:
-					if s.unsupportedEventHandler != nil {
-						s.unsupportedEventHandler(socketEvent)
-					} else {
-						s.logger.Debugf("unsupported event received %+v\n", socketEvent)
-					}
-				}
-			}
-		}
-	}()
+	switch s.transportMode {
+	case TransportModeHTTP:
+		return s.listenHTTP(ctx)
+	default:
+		return s.listenSocketMode(ctx)
+	}
+}
+
+// shutdown stops scheduling jobs, then drains the handlers in flight and the
+// scheduled job runs within the shutdown timeout
+func (s *Slacker) shutdown() {
+	jobsDone := s.jobManager.stop()
+	s.dispatcher.shutdown(s.shutdownTimeout, jobsDone.Done())
+}
 
-	s.startCronJobs(ctx)
-	defer s.cronClient.Stop()
+// Handle processes an event synchronously as if it was received from Slack.
+// Supported events are slackevents.EventsAPIEvent, *slackevents.MessageEvent,
+// *slackevents.AppMentionEvent, *slack.SlashCommand and *slack.InteractionCallback.
+// This is mostly useful for testing bots without connecting to Slack.
+func (s *Slacker) Handle(ctx context.Context, event any) {
+	s.HandleWithAck(ctx, event, nil)
+}
 
-	// blocking call that handles listening for events and placing them in the
-	// Events channel as well as handling outgoing events.
-	return s.socketModeClient.RunContext(ctx)
+// HandleWithAck processes an event like Handle, passing the payload handlers
+// acknowledge the event with to ack, nil if they did not. For instance, the
+// *slack.ViewSubmissionResponse of a view submission.
+func (s *Slacker) HandleWithAck(ctx context.Context, event any, ack func(payload any)) {
+	var eventAcknowledger *acknowledger
+	if ack != nil {
+		eventAcknowledger = &acknowledger{send: ack}
+		defer eventAcknowledger.ack(nil)
+	}
+
+	s.prependHelpHandle()
+
+	switch ev := event.(type) {
+	case slackevents.EventsAPIEvent:
+		socketEvent := socketmode.Event{
+			Type:    socketmode.EventTypeEventsAPI,
+			Data:    ev,
+			Request: &socketmode.Request{Type: socketmode.RequestTypeEventsAPI},
+		}
+		s.traceRoot("event "+ev.InnerEvent.Type, func(ctx context.Context) {
+			s.handleEventsAPIEvent(ctx, socketEvent, ev)
+		})(ctx)
+	case *slack.InteractionCallback:
+		s.traceRoot("interaction "+string(ev.Type), func(ctx context.Context) {
+			s.handleInteractionEvent(ctx, ev, eventAcknowledger)
+		})(ctx)
+	default:
+		s.traceRoot("message", func(ctx context.Context) {
+			s.handleMessageEvent(ctx, event, eventAcknowledger)
+		})(ctx)
+	}
 }
 
 func (s *Slacker) defaultHelp(ctx *CommandContext) {
 	blocks := []slack.Block{}
+	authorizer := newAuthorizer(ctx.Context(), s.logger, s.roleProvider, ctx.Event().UserID)
 
 	for _, group := range s.GetCommandGroups() {
 		for _, command := range group.GetCommands() {
@@ -344,16 +481,11 @@ func (s *Slacker) defaultHelp(ctx *CommandContext) {
 				continue
 			}
 
-			helpMessage := empty
-			tokens := command.Tokenize()
-			for _, token := range tokens {
-				if token.IsParameter() {
-					helpMessage += fmt.Sprintf(codeMessageFormat, token.Word) + space
-				} else {
-					helpMessage += fmt.Sprintf(boldMessageFormat, token.Word) + space
-				}
+			if !authorizer.isAuthorized(group.GetRoles(), command.Definition().Roles) {
+				continue
 			}
 
+			helpMessage := commandUsage(command) + space
 			if len(command.Definition().Description) > 0 {
 				helpMessage += dash + space + fmt.Sprintf(italicMessageFormat, command.Definition().Description)
 			}
@@ -377,6 +509,27 @@ func (s *Slacker) defaultHelp(ctx *CommandContext) {
 		}
 	}
 
+	if len(s.GetReactions()) > 0 {
+		blocks = append(blocks, slack.NewDividerBlock())
+	}
+
+	for _, reaction := range s.GetReactions() {
+		if reaction.Definition().HideHelp {
+			continue
+		}
+
+		helpMessage := fmt.Sprintf(emojiFormat, reaction.emoji)
+		if len(reaction.Definition().Description) > 0 {
+			helpMessage += space + dash + space + fmt.Sprintf(italicMessageFormat, reaction.Definition().Description)
+		}
+
+		blocks = append(blocks,
+			slack.NewSectionBlock(
+				slack.NewTextBlockObject(slack.MarkdownType, helpMessage, false, false),
+				nil, nil,
+			))
+	}
+
 	if len(s.GetJobs()) == 0 {
 		ctx.Response().ReplyBlocks(blocks)
 		return
@@ -408,91 +561,370 @@ func (s *Slacker) defaultHelp(ctx *CommandContext) {
 	ctx.Response().ReplyBlocks(blocks)
 }
 
-func (s *Slacker) prependHelpHandle() {
-	if s.helpDefinition == nil {
-		s.helpDefinition = &CommandDefinition{
-			Command:     helpCommand,
-			Description: helpCommand,
-			Handler:     s.defaultHelp,
+func (s *Slacker) replyUsage(ctx *CommandContext, command Command, err error) {
+	blocks := []slack.Block{
+		slack.NewSectionBlock(
+			slack.NewTextBlockObject(slack.MarkdownType, err.Error(), false, false),
+			nil, nil,
+		),
+		slack.NewSectionBlock(
+			slack.NewTextBlockObject(slack.MarkdownType, fmt.Sprintf(usageMessageFormat, commandUsage(command)), false, false),
+			nil, nil,
+		),
+	}
+
+	parametersMessage := empty
+	for _, parameter := range command.Definition().Parameters {
+		if len(parameter.Description) == 0 {
+			continue
+		}
+		parametersMessage += fmt.Sprintf(codeMessageFormat, parameter.Usage()) + space + dash + space + parameter.Description + newLine
+	}
+
+	if len(parametersMessage) > 0 {
+		blocks = append(blocks, slack.NewContextBlock("",
+			slack.NewTextBlockObject(slack.MarkdownType, parametersMessage, false, false),
+		))
+	}
+
+	ctx.Response().ReplyBlocks(blocks, WithEphemeral())
+}
+
+func (s *Slacker) validateParameters(command Command) CommandMiddlewareHandler {
+	return func(next CommandHandler) CommandHandler {
+		return func(ctx *CommandContext) {
+			if err := validateParameters(command.Definition().Parameters, ctx.Request().Properties()); err != nil {
+				s.replyUsage(ctx, command, err)
+				return
+			}
+			next(ctx)
 		}
 	}
+}
 
-	s.commandGroups[0].PrependCommand(s.helpDefinition)
+func (s *Slacker) prependHelpHandle() {
+	s.helpOnce.Do(func() {
+		if s.helpDefinition == nil {
+			s.helpDefinition = &CommandDefinition{
+				Command:     helpCommand,
+				Description: helpCommand,
+				Handler:     s.defaultHelp,
+			}
+		}
+
+		s.commandGroups[0].PrependCommand(s.helpDefinition)
+	})
 }
 
-func (s *Slacker) startCronJobs(ctx context.Context) {
+// runJob runs the job in the current goroutine, unless shutting down, retrying
+// failed attempts with an exponential backoff
+func (s *Slacker) runJob(definition *JobDefinition) {
 	middlewares := make([]JobMiddlewareHandler, 0)
 	middlewares = append(middlewares, s.jobMiddlewares...)
+	middlewares = append(middlewares, definition.Middlewares...)
+	name := jobTaskPrefix + definition.Name
+
+	s.dispatcher.run(name, s.traceRoot(name, func(ctx context.Context) {
+		backoff := definition.RetryBackoff
+		for attempt := 1; ; attempt++ {
+			err := s.runJobAttempt(ctx, definition, attempt, middlewares)
+			if err == nil || attempt > definition.Retries {
+				return
+			}
 
-	for _, job := range s.jobs {
-		definition := job.Definition()
-		middlewares = append(middlewares, definition.Middlewares...)
-		jobCtx := newJobContext(ctx, s.logger, s.slackClient, definition)
-		_, err := s.cronClient.AddFunc(definition.CronExpression, executeJob(jobCtx, definition.Handler, middlewares...))
-		if err != nil {
-			s.logger.Errorf(err.Error())
+			s.logger.Info("retrying job", logKeyJob, definition.Name, logKeyAttempt, attempt, "backoff", backoff, logKeyError, err)
+
+			timer := time.NewTimer(backoff)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+			backoff *= 2
 		}
+	}))
+}
+
+// runJobAttempt runs an attempt of the job, canceling its context once the
+// job's timeout elapses. It returns the error reported by the handler, if any.
+func (s *Slacker) runJobAttempt(ctx context.Context, definition *JobDefinition, attempt int, middlewares []JobMiddlewareHandler) error {
+	s.metrics.jobRun(definition.Name)
+	defer s.metrics.handlerDone(ContextTypeJob, definition.Name, time.Now())
 
+	if definition.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, definition.Timeout)
+		defer cancel()
 	}
 
-	s.cronClient.Start()
+	spanCtx, span := StartSpan(ctx, spanHandler)
+	jobCtx := newJobContext(spanCtx, s.logger, s.metrics, s.slackClient, definition, s.store, attempt)
+	executeJob(jobCtx, s.errorReporter, definition.Handler, middlewares...)()
+	endSpan(span, jobCtx.err)
+	return jobCtx.err
 }
 
-func (s *Slacker) handleInteractionEvent(ctx context.Context, callback *slack.InteractionCallback) {
-	middlewares := make([]InteractionMiddlewareHandler, 0)
-	middlewares = append(middlewares, s.interactionMiddlewares...)
+func (s *Slacker) handleEventsAPIEvent(ctx context.Context, socketEvent socketmode.Event, event slackevents.EventsAPIEvent) {
+	if event.Type != slackevents.CallbackEvent {
+		s.handleUnsupportedEvent(socketEvent)
+		return
+	}
 
-	var interaction *Interaction
-	var definition *InteractionDefinition
+	switch event.InnerEvent.Type {
+	case "message", "app_mention": // message-based events
+		s.handleMessageEvent(ctx, event.InnerEvent.Data, nil)
 
-	switch callback.Type {
-	case slack.InteractionTypeBlockActions:
-		for _, i := range s.interactions[callback.Type] {
-			for _, a := range callback.ActionCallback.BlockActions {
-				definition = i.Definition()
-				if a.BlockID == definition.InteractionID {
-					interaction = i
-					break
-				}
-			}
-			if interaction != nil {
-				break
-			}
+	default:
+		s.invalidateCache(event.InnerEvent.Data)
+
+		handled := false
+		if reactionEvent, ok := event.InnerEvent.Data.(*slackevents.ReactionAddedEvent); ok {
+			handled = s.handleReactionEvent(ctx, reactionEvent)
 		}
-	case slack.InteractionTypeViewClosed, slack.InteractionTypeViewSubmission:
-		for _, i := range s.interactions[callback.Type] {
-			definition = i.Definition()
-			if definition.InteractionID == callback.View.CallbackID {
-				interaction = i
-				break
-			}
+
+		if homeEvent, ok := event.InnerEvent.Data.(*slackevents.AppHomeOpenedEvent); ok {
+			handled = s.handleAppHomeEvent(ctx, homeEvent)
+		}
+
+		if functionEvent, ok := event.InnerEvent.Data.(*FunctionExecutedEvent); ok {
+			handled = s.handleFunctionEvent(ctx, functionEvent)
+		}
+
+		if events := s.events[slackevents.EventsAPIType(event.InnerEvent.Type)]; len(events) > 0 {
+			s.handleEvent(ctx, &event, events)
+			handled = true
+		}
+
+		if !handled {
+			s.handleUnsupportedEvent(socketEvent)
 		}
-	case slack.InteractionTypeShortcut, slack.InteractionTypeMessageAction:
-		for _, i := range s.interactions[callback.Type] {
-			definition = i.Definition()
-			if definition.InteractionID == callback.CallbackID {
-				interaction = i
-				break
+	}
+}
+
+// handleReactionEvent runs the reactions matching the event, returning false if none did
+func (s *Slacker) handleReactionEvent(ctx context.Context, event *slackevents.ReactionAddedEvent) bool {
+	if event.Item.Type != slack.TYPE_MESSAGE {
+		return false
+	}
+
+	var message *ReactionMessage
+	matched := false
+
+	for _, reaction := range s.reactions {
+		if !reaction.Match(event) {
+			continue
+		}
+		matched = true
+
+		if message == nil {
+			var err error
+			message, err = getReactionMessage(ctx, s.logger, s.slackClient, event.Item.Channel, event.Item.Timestamp)
+			if err != nil {
+				s.logger.Error("unable to get reacted message", logKeyChannelID, event.Item.Channel, logKeyError, err)
+				return true
 			}
 		}
+
+		s.runReaction(ctx, event, message, reaction.Definition())
 	}
+	return matched
+}
+
+func (s *Slacker) runReaction(ctx context.Context, event *slackevents.ReactionAddedEvent, message *ReactionMessage, definition *ReactionDefinition) {
+	emoji := normalizeEmoji(definition.Emoji)
+	s.metrics.reactionMatched(emoji)
+	defer s.metrics.handlerDone(ContextTypeReaction, emoji, time.Now())
+
+	middlewares := make([]ReactionMiddlewareHandler, 0)
+	middlewares = append(middlewares, s.reactionMiddlewares...)
+	middlewares = append(middlewares, definition.Middlewares...)
+
+	spanCtx, span := StartSpan(ctx, spanHandler)
+	reactionCtx := newReactionContext(spanCtx, s.logger, s.metrics, s.slackClient, event, message, definition)
+	executeReaction(reactionCtx, s.errorReporter, definition.Handler, middlewares...)
+	endSpan(span, reactionCtx.err)
+}
 
-	if interaction != nil {
-		interactionCtx := newInteractionContext(ctx, s.logger, s.slackClient, callback, definition)
+func (s *Slacker) handleEvent(ctx context.Context, event *slackevents.EventsAPIEvent, events []*Event) {
+	s.metrics.eventDispatched(event.InnerEvent.Type)
+	defer s.metrics.handlerDone(ContextTypeEvent, event.InnerEvent.Type, time.Now())
+
+	for _, e := range events {
+		definition := e.Definition()
+
+		middlewares := make([]EventMiddlewareHandler, 0)
+		middlewares = append(middlewares, s.eventMiddlewares...)
 		middlewares = append(middlewares, definition.Middlewares...)
-		executeInteraction(interactionCtx, definition.Handler, middlewares...)
+
+		spanCtx, span := StartSpan(ctx, spanHandler)
+		eventCtx := newEventContext(spanCtx, s.logger, s.metrics, s.slackClient, event, definition)
+		executeEvent(eventCtx, s.errorReporter, definition.Handler, middlewares...)
+		endSpan(span, eventCtx.err)
+	}
+}
+
+// invalidateCache forgets cached lookups that the event reports as changed
+func (s *Slacker) invalidateCache(event any) {
+	if s.cache == nil {
+		return
+	}
+
+	switch ev := event.(type) {
+	case *slack.UserChangeEvent:
+		s.cache.Delete(userCacheKey + ev.User.ID)
+	case *slackevents.UserProfileChangedEvent:
+		if ev.User != nil {
+			s.cache.Delete(userCacheKey + ev.User.ID)
+		}
+	case *slackevents.ChannelRenameEvent:
+		s.cache.Delete(channelCacheKey + ev.Channel.ID)
+	case *slackevents.ChannelArchiveEvent:
+		s.cache.Delete(channelCacheKey + ev.Channel)
+	case *slackevents.ChannelUnarchiveEvent:
+		s.cache.Delete(channelCacheKey + ev.Channel)
+	case *slackevents.ChannelDeletedEvent:
+		s.cache.Delete(channelCacheKey + ev.Channel)
+	}
+}
+
+func (s *Slacker) handleUnsupportedEvent(socketEvent socketmode.Event) {
+	if s.unsupportedEventHandler != nil {
+		s.unsupportedEventHandler(socketEvent)
+	} else {
+		s.logger.Debug("unsupported event received", "event", socketEvent)
+	}
+}
+
+func (s *Slacker) handleInteractionEvent(ctx context.Context, callback *slack.InteractionCallback, acknowledger *acknowledger) {
+	defer acknowledger.ack(nil)
+
+	if callback.Type == slack.InteractionTypeBlockSuggestion && s.handleOptionsEvent(ctx, callback, acknowledger) {
+		return
+	}
+
+	_, span := StartSpan(ctx, spanMatch)
+	matches := s.matchInteractions(callback)
+	span.SetAttributes(Attr(attributeMatched, len(matches) > 0))
+	span.End()
+
+	for _, match := range matches {
+		s.runInteraction(ctx, callback, match.interaction.Definition(), match.match, acknowledger)
+	}
+
+	if len(matches) > 0 {
+		if isAppHomeAction(callback) && s.appHome != nil && s.appHome.RefreshOnAction {
+			s.publishAppHome(ctx, callback.User.ID, nil, appHomeTriggerAction)
+		}
 		return
 	}
 
-	s.logger.Debugf("unsupported interaction type received %s\n", callback.Type)
+	s.logger.Debug("unsupported interaction type received", logKeyInteractionType, callback.Type)
 	if s.unsupportedInteractionHandler != nil {
-		interactionCtx := newInteractionContext(ctx, s.logger, s.slackClient, callback, nil)
-		executeInteraction(interactionCtx, s.unsupportedInteractionHandler, middlewares...)
+		spanCtx, span := StartSpan(ctx, spanHandler)
+		interactionCtx := newInteractionContext(spanCtx, s.logger, s.metrics, s.slackClient, callback, nil, nil, s.store, acknowledger)
+		executeInteraction(interactionCtx, s.errorReporter, s.unsupportedInteractionHandler, s.interactionMiddlewares...)
+		endSpan(span, interactionCtx.err)
+	}
+}
+
+// interactionMatch contains an interaction matching a callback, and what matched
+type interactionMatch struct {
+	interaction *Interaction
+	match       *InteractionMatch
+}
+
+// matchInteractions returns the interactions handling the callback. Each action
+// of a block actions callback is matched to the first interaction handling it.
+func (s *Slacker) matchInteractions(callback *slack.InteractionCallback) []*interactionMatch {
+	matches := []*interactionMatch{}
+	if callback.Type == slack.InteractionTypeBlockActions {
+		for _, action := range callback.ActionCallback.BlockActions {
+			for _, interaction := range s.interactions[callback.Type] {
+				if match, ok := interaction.MatchAction(action); ok {
+					matches = append(matches, &interactionMatch{interaction: interaction, match: match})
+					break
+				}
+			}
+		}
+		return matches
+	}
+
+	for _, interaction := range s.interactions[callback.Type] {
+		if match, ok := interaction.Match(callback); ok {
+			return append(matches, &interactionMatch{interaction: interaction, match: match})
+		}
+	}
+	return matches
+}
+
+func (s *Slacker) runInteraction(
+	ctx context.Context,
+	callback *slack.InteractionCallback,
+	definition *InteractionDefinition,
+	match *InteractionMatch,
+	acknowledger *acknowledger,
+) {
+	s.metrics.interactionDispatched(definition.name(), string(callback.Type))
+	defer s.metrics.handlerDone(ContextTypeInteraction, definition.name(), time.Now())
+
+	middlewares := make([]InteractionMiddlewareHandler, 0)
+	middlewares = append(middlewares, s.interactionMiddlewares...)
+
+	spanCtx, span := StartSpan(ctx, spanHandler)
+	interactionCtx := newInteractionContext(spanCtx, s.logger, s.metrics, s.slackClient, callback, definition, match, s.store, acknowledger)
+	defer func() { endSpan(span, interactionCtx.err) }()
+
+	authorizer := newAuthorizer(ctx, s.logger, s.roleProvider, callback.User.ID)
+	if !authorizer.isAuthorized(definition.Roles) {
+		executeInteraction(interactionCtx, s.errorReporter, s.unauthorizedInteractionHandler, middlewares...)
+		return
+	}
+
+	middlewares = append(middlewares, definition.Middlewares...)
+	executeInteraction(interactionCtx, s.errorReporter, definition.Handler, middlewares...)
+}
+
+// handleOptionsEvent suggests the options of the first definition matching
+// the block suggestion, returning false if none did
+func (s *Slacker) handleOptionsEvent(ctx context.Context, callback *slack.InteractionCallback, acknowledger *acknowledger) bool {
+	_, span := StartSpan(ctx, spanMatch)
+	var options *Options
+	var match *InteractionMatch
+	for _, candidate := range s.options {
+		if candidateMatch, ok := candidate.Match(callback); ok {
+			options, match = candidate, candidateMatch
+			break
+		}
+	}
+	span.SetAttributes(Attr(attributeMatched, options != nil))
+	span.End()
+
+	if options == nil {
+		return false
 	}
+
+	definition := options.Definition()
+	s.metrics.optionsSuggested(definition.ActionID)
+	defer s.metrics.handlerDone(ContextTypeOptions, definition.ActionID, time.Now())
+
+	middlewares := make([]OptionsMiddlewareHandler, 0)
+	middlewares = append(middlewares, s.optionsMiddlewares...)
+	middlewares = append(middlewares, definition.Middlewares...)
+
+	spanCtx, span := StartSpan(ctx, spanHandler)
+	optionsCtx := newOptionsContext(spanCtx, s.logger, s.metrics, s.slackClient, callback, definition, match)
+	executeOptions(optionsCtx, s.errorReporter, definition.Handler, middlewares...)
+	endSpan(span, optionsCtx.err)
+
+	acknowledger.ack(optionsCtx.payload())
+	return true
 }
 
-func (s *Slacker) handleMessageEvent(ctx context.Context, event any) {
-	messageEvent := newMessageEvent(s.logger, s.slackClient, event)
+func (s *Slacker) handleMessageEvent(ctx context.Context, event any, acknowledger *acknowledger) {
+	defer acknowledger.ack(nil)
+
+	messageEvent := newMessageEvent(ctx, s.logger, s.slackClient, s.cache, s.lazyLookups, event)
 	if messageEvent == nil {
 		// event doesn't appear to be a valid message type
 		return
@@ -504,51 +936,186 @@ func (s *Slacker) handleMessageEvent(ctx context.Context, event any) {
 		}
 	}
 
+	if s.handleConversation(ctx, messageEvent) {
+		return
+	}
+
 	middlewares := make([]CommandMiddlewareHandler, 0)
 	middlewares = append(middlewares, s.commandMiddlewares...)
 
-	eventText := s.sanitizeEventTextHandler(messageEvent.Text)
-	for _, group := range s.commandGroups {
-		for _, cmd := range group.GetCommands() {
-			parameters, isMatch := cmd.Match(eventText)
-			if !isMatch {
-				continue
-			}
+	_, span := StartSpan(ctx, spanMatch)
+	group, cmd, parameters := s.matchCommand(s.sanitizeEventTextHandler(messageEvent.Text))
+	span.SetAttributes(Attr(attributeMatched, cmd != nil))
+	span.End()
+
+	if cmd != nil {
+		definition := cmd.Definition()
+		s.metrics.commandMatched(definition.Command)
+		defer s.metrics.handlerDone(ContextTypeCommand, definition.Command, time.Now())
 
-			definition := cmd.Definition()
-			ctx := newCommandContext(ctx, s.logger, s.slackClient, messageEvent, definition, parameters)
+		spanCtx, span := StartSpan(ctx, spanHandler)
+		ctx := newCommandContext(spanCtx, s.logger, s.metrics, s.slackClient, messageEvent, definition, parameters, s.store, s.conversations, acknowledger)
+		defer func() { endSpan(span, ctx.err) }()
 
-			middlewares = append(middlewares, group.GetMiddlewares()...)
-			middlewares = append(middlewares, definition.Middlewares...)
-			executeCommand(ctx, definition.Handler, middlewares...)
+		authorizer := newAuthorizer(ctx.Context(), s.logger, s.roleProvider, messageEvent.UserID)
+		if !authorizer.isAuthorized(group.GetRoles(), definition.Roles) {
+			executeCommand(ctx, s.errorReporter, s.unauthorizedCommandHandler, middlewares...)
 			return
 		}
+
+		middlewares = append(middlewares, group.GetMiddlewares()...)
+		middlewares = append(middlewares, definition.Middlewares...)
+		middlewares = append(middlewares, s.validateParameters(cmd))
+		executeCommand(ctx, s.errorReporter, definition.Handler, middlewares...)
+		return
 	}
 
+	s.metrics.commandUnsupported()
 	if s.unsupportedCommandHandler != nil {
-		ctx := newCommandContext(ctx, s.logger, s.slackClient, messageEvent, nil, nil)
-		executeCommand(ctx, s.unsupportedCommandHandler, middlewares...)
+		spanCtx, span := StartSpan(ctx, spanHandler)
+		ctx := newCommandContext(spanCtx, s.logger, s.metrics, s.slackClient, messageEvent, nil, nil, s.store, s.conversations, acknowledger)
+		executeCommand(ctx, s.errorReporter, s.unsupportedCommandHandler, middlewares...)
+		endSpan(span, ctx.err)
+	}
+}
+
+// handleConversation hands the message to the current step of the user's
+// conversation in the thread, returning false if there is none
+func (s *Slacker) handleConversation(ctx context.Context, event *MessageEvent) bool {
+	if len(s.conversations.conversations) == 0 || !event.InThread() {
+		return false
+	}
+
+	store := s.conversations.store
+	key := conversationKey(event.ChannelID, event.ThreadTimeStamp, event.UserID)
+
+	state, ok, err := store.Get(ctx, key)
+	if err != nil {
+		s.logger.Error("unable to get conversation", logKeyChannelID, event.ChannelID, logKeyUserID, event.UserID, logKeyError, err)
+		return false
+	}
+
+	if !ok {
+		return false
+	}
+
+	conversation, ok := s.conversations.conversations[state.Name]
+	if !ok {
+		s.logger.Error(conversationNotFound, logKeyConversation, state.Name)
+		s.deleteConversation(ctx, key)
+		return false
+	}
+
+	definition := conversation.Definition()
+	writer := newWriter(ctx, s.logger, s.metrics, s.slackClient)
+
+	if s.conversations.clock().After(state.ExpiresAt) {
+		s.deleteConversation(ctx, key)
+		writer.Post(event.ChannelID, definition.TimeoutMessage, SetThreadTS(state.ThreadTimeStamp))
+		return true
+	}
+
+	if conversation.IsCancel(event.Text) {
+		s.deleteConversation(ctx, key)
+		writer.Post(event.ChannelID, definition.CancelMessage, SetThreadTS(state.ThreadTimeStamp))
+		return true
+	}
+
+	step := conversation.step(state.Step)
+	if step == nil {
+		s.logger.Error(conversationStepNotFound, logKeyConversation, state.Name, logKeyStep, state.Step)
+		s.deleteConversation(ctx, key)
+		return false
+	}
+
+	s.runConversationStep(ctx, key, event, state, conversation, step)
+	return true
+}
+
+func (s *Slacker) runConversationStep(
+	ctx context.Context,
+	key string,
+	event *MessageEvent,
+	state *ConversationState,
+	conversation *Conversation,
+	step *ConversationStep,
+) {
+	definition := conversation.Definition()
+	s.metrics.conversationStep(definition.Name, step.Name)
+	defer s.metrics.handlerDone(ContextTypeConversation, definition.Name, time.Now())
+
+	middlewares := make([]ConversationMiddlewareHandler, 0)
+	middlewares = append(middlewares, s.conversationMiddlewares...)
+	middlewares = append(middlewares, definition.Middlewares...)
+
+	spanCtx, span := StartSpan(ctx, spanHandler)
+	conversationCtx := newConversationContext(spanCtx, s.logger, s.metrics, s.slackClient, event, state, definition)
+	executeConversation(conversationCtx, s.errorReporter, step.Handler, middlewares...)
+	endSpan(span, conversationCtx.err)
+
+	if conversationCtx.ended {
+		s.deleteConversation(ctx, key)
+		return
+	}
+
+	next := conversation.step(conversationCtx.step)
+	if next == nil {
+		s.logger.Error(conversationStepNotFound, logKeyConversation, definition.Name, logKeyStep, conversationCtx.step)
+		s.deleteConversation(ctx, key)
+		return
+	}
+
+	moved := next.Name != state.Step
+	state.Step = next.Name
+	state.ExpiresAt = s.conversations.clock().Add(definition.Timeout)
+
+	if err := s.conversations.store.Set(ctx, key, state); err != nil {
+		s.logger.Error("unable to save conversation", logKeyConversation, definition.Name, logKeyError, err)
+		return
+	}
+
+	if moved && len(next.Prompt) > 0 {
+		conversationCtx.Response().Reply(next.Prompt)
+	}
+}
+
+func (s *Slacker) deleteConversation(ctx context.Context, key string) {
+	if err := s.conversations.store.Delete(ctx, key); err != nil {
+		s.logger.Error("unable to delete conversation", logKeyError, err)
 	}
 }
 
+// matchCommand returns the first command matching the text, along with its group and parameters
+func (s *Slacker) matchCommand(text string) (*CommandGroup, Command, *proper.Properties) {
+	for _, group := range s.commandGroups {
+		for _, cmd := range group.GetCommands() {
+			parameters, isMatch := cmd.Match(text)
+			if isMatch {
+				return group, cmd, parameters
+			}
+		}
+	}
+	return nil, nil, nil
+}
+
 func (s *Slacker) ignoreBotMessage(messageEvent *MessageEvent) bool {
 	switch s.botInteractionMode {
 	case BotModeIgnoreApp:
 		bot, err := s.slackClient.GetBotInfo(messageEvent.BotID)
 		if err != nil {
 			if err.Error() == "missing_scope" {
-				s.logger.Errorf("unable to determine if bot response is from me -- please add users:read scope to your app\n")
+				s.logger.Error("unable to determine if bot response is from me -- please add users:read scope to your app")
 			} else {
-				s.logger.Debugf("unable to get information on the bot that sent message: %v\n", err)
+				s.logger.Debug("unable to get information on the bot that sent message", logKeyError, err)
 			}
 			return true
 		}
 		if bot.AppID == s.appID {
-			s.logger.Debugf("ignoring event that originated from my App ID: %v\n", bot.AppID)
+			s.logger.Debug("ignoring event that originated from my App ID", "app_id", bot.AppID)
 			return true
 		}
 	case BotModeIgnoreAll:
-		s.logger.Debugf("ignoring event that originated from Bot ID: %v\n", messageEvent.BotID)
+		s.logger.Debug("ignoring event that originated from Bot ID", "bot_id", messageEvent.BotID)
 		return true
 	default:
 		// BotInteractionModeIgnoreNone is handled in the default case
@@ -568,6 +1135,30 @@ func newSlackOptions(appToken string, options *clientOptions) []slack.Option {
 	return slackOptions
 }
 
+// commandUsage formats the command's tokens, including the declared parameter types
+func commandUsage(command Command) string {
+	parameters := make(map[string]*ParameterDefinition)
+	for _, parameter := range command.Definition().Parameters {
+		parameters[parameter.Name] = parameter
+	}
+
+	tokens := make([]string, 0)
+	for _, token := range command.Tokenize() {
+		if !token.IsParameter() {
+			tokens = append(tokens, fmt.Sprintf(boldMessageFormat, token.Word))
+			continue
+		}
+
+		parameter, ok := parameters[token.Word]
+		if !ok {
+			tokens = append(tokens, fmt.Sprintf(codeMessageFormat, token.Word))
+			continue
+		}
+		tokens = append(tokens, fmt.Sprintf(codeMessageFormat, parameter.Usage()))
+	}
+	return strings.Join(tokens, space)
+}
+
 func defaultEventTextSanitizer(msg string) string {
 	return strings.ReplaceAll(msg, "\u00a0", " ")
 }
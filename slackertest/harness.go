@@ -0,0 +1,209 @@
+package slackertest
+
+import (
+	"context"
+
+	"github.com/shomali11/slacker/v2"
+	"github.com/slack-go/slack"
+	"github.com/slack-go/slack/slackevents"
+)
+
+const (
+	testBotToken = "xoxb-test"
+	testAppToken = "xapp-test"
+)
+
+// NewHarness creates a bot backed by a fake Slack API. Options are applied
+// after the harness's own, so they can override everything but the API URL.
+func NewHarness(clientOptions ...slacker.ClientOption) *Harness {
+	server := NewServer()
+
+	options := []slacker.ClientOption{slacker.WithBotMode(slacker.BotModeIgnoreAll)}
+	options = append(options, clientOptions...)
+	options = append(options, slacker.WithAPIURL(server.URL()))
+
+	return &Harness{
+		server: server,
+		bot:    slacker.NewClient(testBotToken, testAppToken, options...),
+	}
+}
+
+// Harness feeds synthetic events into a bot and records what it sends back
+type Harness struct {
+	server *Server
+	bot    *slacker.Slacker
+}
+
+// Bot returns the bot under test, used to register commands, interactions and middlewares
+func (h *Harness) Bot() *slacker.Slacker {
+	return h.bot
+}
+
+// Server returns the fake Slack API
+func (h *Harness) Server() *Server {
+	return h.server
+}
+
+// Close shuts down the fake Slack API
+func (h *Harness) Close() {
+	h.server.Close()
+}
+
+// Messages returns every message the bot posted, updated, scheduled or deleted
+func (h *Harness) Messages() []*Message {
+	return h.server.Messages()
+}
+
+// Reset forgets the recorded calls, messages and modals
+func (h *Harness) Reset() {
+	h.server.Reset()
+}
+
+// SendMessage sends a channel message from a user and waits for the bot to handle it.
+// It returns the timestamp of the sent message.
+func (h *Harness) SendMessage(channelID string, userID string, text string) string {
+	return h.SendThreadMessage(channelID, userID, "", text)
+}
+
+// SendThreadMessage sends a message inside a thread and waits for the bot to handle it.
+// It returns the timestamp of the sent message.
+func (h *Harness) SendThreadMessage(channelID string, userID string, threadTimeStamp string, text string) string {
+	timeStamp := h.server.NextTimeStamp()
+	h.server.AddMessage(channelID, slack.Message{Msg: slack.Msg{
+		User:            userID,
+		Text:            text,
+		Timestamp:       timeStamp,
+		ThreadTimestamp: threadTimeStamp,
+	}})
+	h.SendEvent(&slackevents.MessageEvent{
+		Type:            "message",
+		Channel:         channelID,
+		User:            userID,
+		Text:            text,
+		TimeStamp:       timeStamp,
+		ThreadTimeStamp: threadTimeStamp,
+	})
+	return timeStamp
+}
+
+// SendMention sends an `app_mention` event and waits for the bot to handle it.
+// It returns the timestamp of the sent message.
+func (h *Harness) SendMention(channelID string, userID string, text string) string {
+	timeStamp := h.server.NextTimeStamp()
+	h.SendEvent(&slackevents.AppMentionEvent{
+		Type:      "app_mention",
+		Channel:   channelID,
+		User:      userID,
+		Text:      text,
+		TimeStamp: timeStamp,
+	})
+	return timeStamp
+}
+
+// SendReaction sends a `reaction_added` event for a message and waits for the bot to handle it.
+// The message is expected to be known by the fake Slack API, for instance sent with SendMessage.
+func (h *Harness) SendReaction(channelID string, userID string, timeStamp string, emoji string) {
+	h.SendEvent(slackevents.EventsAPIEvent{
+		Type: slackevents.CallbackEvent,
+		InnerEvent: slackevents.EventsAPIInnerEvent{
+			Type: string(slackevents.ReactionAdded),
+			Data: &slackevents.ReactionAddedEvent{
+				Type:     string(slackevents.ReactionAdded),
+				User:     userID,
+				Reaction: emoji,
+				Item: slackevents.Item{
+					Type:      slack.TYPE_MESSAGE,
+					Channel:   channelID,
+					Timestamp: timeStamp,
+				},
+				EventTimestamp: h.server.NextTimeStamp(),
+			},
+		},
+	})
+}
+
+// OpenAppHome sends an `app_home_opened` event for the home tab and waits for the bot to handle it
+func (h *Harness) OpenAppHome(userID string) {
+	h.SendEvent(slackevents.EventsAPIEvent{
+		Type: slackevents.CallbackEvent,
+		InnerEvent: slackevents.EventsAPIInnerEvent{
+			Type: string(slackevents.AppHomeOpened),
+			Data: &slackevents.AppHomeOpenedEvent{
+				Type:           string(slackevents.AppHomeOpened),
+				User:           userID,
+				Tab:            "home",
+				EventTimeStamp: h.server.NextTimeStamp(),
+			},
+		},
+	})
+}
+
+// RunJob runs the named job of the bot and waits for it to complete, retries
+// included. Paused jobs run too.
+func (h *Harness) RunJob(name string) error {
+	return h.bot.JobManager().RunAndWait(name)
+}
+
+// ExecuteFunction sends a `function_executed` event running the custom step
+// with the inputs and waits for the bot to handle it. It returns the ID of the
+// run, to look up its result with Server.FunctionResult.
+func (h *Harness) ExecuteFunction(callbackID string, inputs map[string]any) string {
+	functionExecutionID := "Fx" + h.server.NextTimeStamp()
+	h.SendEvent(slackevents.EventsAPIEvent{
+		Type: slackevents.CallbackEvent,
+		InnerEvent: slackevents.EventsAPIInnerEvent{
+			Type: string(slacker.FunctionExecuted),
+			Data: &slacker.FunctionExecutedEvent{
+				Type:                string(slacker.FunctionExecuted),
+				Function:            slacker.ExecutedFunction{CallbackID: callbackID, AppID: TestAppID},
+				Inputs:              inputs,
+				FunctionExecutionID: functionExecutionID,
+				EventTimeStamp:      h.server.NextTimeStamp(),
+				BotAccessToken:      TestFunctionToken,
+			},
+		},
+	})
+	return functionExecutionID
+}
+
+// SendSlashCommand sends a slash command and waits for the bot to handle it,
+// returning the payload it was acknowledged with. It is nil when acknowledged
+// without a payload. The command is expected to include the leading slash, for
+// instance `/hello`. Responses are recorded as messages of the channel.
+func (h *Harness) SendSlashCommand(channelID string, userID string, command string, text string) any {
+	var payload any
+	h.bot.HandleWithAck(context.Background(), &slack.SlashCommand{
+		TeamID:      TestTeamID,
+		ChannelID:   channelID,
+		UserID:      userID,
+		Command:     command,
+		Text:        text,
+		APIAppID:    TestAppID,
+		TriggerID:   TestTriggerID,
+		ResponseURL: h.server.ResponseURL(channelID, userID),
+	}, func(ackPayload any) {
+		payload = ackPayload
+	})
+	return payload
+}
+
+// SendInteraction sends an interaction callback and waits for the bot to handle it
+func (h *Harness) SendInteraction(callback *slack.InteractionCallback) {
+	h.SendEvent(callback)
+}
+
+// SubmitInteraction sends an interaction callback, for instance a view
+// submission, waits for the bot to handle it and returns the payload it was
+// acknowledged with. It is nil when acknowledged without a payload.
+func (h *Harness) SubmitInteraction(callback *slack.InteractionCallback) any {
+	var payload any
+	h.bot.HandleWithAck(context.Background(), callback, func(ackPayload any) {
+		payload = ackPayload
+	})
+	return payload
+}
+
+// SendEvent sends any event supported by slacker.Slacker.Handle and waits for the bot to handle it
+func (h *Harness) SendEvent(event any) {
+	h.bot.Handle(context.Background(), event)
+}
@@ -0,0 +1,297 @@
+package slackertest_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/shomali11/slacker/v2"
+	"github.com/shomali11/slacker/v2/slackertest"
+	"github.com/slack-go/slack"
+)
+
+const (
+	testChannelID = "C123"
+	testUserID    = "U123"
+)
+
+func TestSendMessage(t *testing.T) {
+	harness := slackertest.NewHarness()
+	defer harness.Close()
+
+	harness.Bot().AddCommand(&slacker.CommandDefinition{
+		Command: "echo {word}",
+		Handler: func(ctx *slacker.CommandContext) {
+			ctx.Response().Reply(ctx.Request().Param("word"), slacker.WithInThread(true))
+		},
+	})
+
+	timeStamp := harness.SendMessage(testChannelID, testUserID, "echo hello")
+
+	messages := harness.Messages()
+	if len(messages) != 1 {
+		t.Fatalf("expected 1 message, got %d", len(messages))
+	}
+
+	message := messages[0]
+	if message.Method != "chat.postMessage" || message.Channel != testChannelID || message.Text != "hello" {
+		t.Errorf("unexpected message %+v", message)
+	}
+	if message.ThreadTimeStamp != timeStamp {
+		t.Errorf("expected the reply in thread %s, got %s", timeStamp, message.ThreadTimeStamp)
+	}
+}
+
+func TestSendMessageWriterCalls(t *testing.T) {
+	harness := slackertest.NewHarness()
+	defer harness.Close()
+
+	harness.Bot().AddCommand(&slacker.CommandDefinition{
+		Command: "writer",
+		Handler: func(ctx *slacker.CommandContext) {
+			timeStamp, err := ctx.Response().Reply("first")
+			if err != nil {
+				t.Errorf("unexpected error %v", err)
+				return
+			}
+
+			ctx.Response().Reply("second", slacker.WithReplace(timeStamp))
+			ctx.Response().Reply("secret", slacker.WithEphemeral())
+			ctx.Response().ReplyBlocks([]slack.Block{
+				slack.NewSectionBlock(slack.NewTextBlockObject(slack.MarkdownType, "block", false, false), nil, nil),
+			})
+			ctx.Response().Delete(testChannelID, timeStamp)
+		},
+	})
+
+	harness.SendMessage(testChannelID, testUserID, "writer")
+
+	messages := harness.Messages()
+	if len(messages) != 5 {
+		t.Fatalf("expected 5 messages, got %d", len(messages))
+	}
+
+	if !messages[1].IsUpdate() || messages[1].TimeStamp != messages[0].TimeStamp || messages[1].Text != "second" {
+		t.Errorf("expected an update of the first message, got %+v", messages[1])
+	}
+	if !messages[2].IsEphemeral() || messages[2].EphemeralUserID != testUserID {
+		t.Errorf("expected an ephemeral message to %s, got %+v", testUserID, messages[2])
+	}
+	if len(messages[3].Blocks) != 1 || messages[3].Blocks[0].BlockType() != slack.MBTSection {
+		t.Errorf("expected a section block, got %+v", messages[3].Blocks)
+	}
+	if !messages[4].IsDelete() || messages[4].TimeStamp != messages[0].TimeStamp {
+		t.Errorf("expected a delete of the first message, got %+v", messages[4])
+	}
+}
+
+func TestSendSlashCommand(t *testing.T) {
+	harness := slackertest.NewHarness()
+	defer harness.Close()
+
+	harness.Bot().AddCommand(&slacker.CommandDefinition{
+		Command: "greet {name}",
+		Handler: func(ctx *slacker.CommandContext) {
+			ctx.Response().Reply("hello " + ctx.Request().Param("name"))
+		},
+	})
+
+	payload := harness.SendSlashCommand(testChannelID, testUserID, "/greet", "world")
+	if payload != nil {
+		t.Errorf("expected no acknowledgement payload, got %+v", payload)
+	}
+
+	messages := harness.Messages()
+	if len(messages) != 1 {
+		t.Fatalf("expected 1 message, got %d", len(messages))
+	}
+
+	message := messages[0]
+	if message.Method != "response_url" || message.Channel != testChannelID || message.Text != "hello world" {
+		t.Errorf("unexpected message %+v", message)
+	}
+	if message.IsEphemeral() {
+		t.Errorf("expected a response visible in the channel, got %+v", message)
+	}
+}
+
+func TestSendInteraction(t *testing.T) {
+	harness := slackertest.NewHarness()
+	defer harness.Close()
+
+	harness.Bot().AddInteraction(&slacker.InteractionDefinition{
+		Type:     slack.InteractionTypeBlockActions,
+		ActionID: "approve",
+		Handler: func(ctx *slacker.InteractionContext) {
+			ctx.Response().Reply("approved " + ctx.Action().Value)
+		},
+	})
+
+	callback := &slack.InteractionCallback{Type: slack.InteractionTypeBlockActions}
+	callback.Channel.ID = testChannelID
+	callback.User.ID = testUserID
+	callback.ActionCallback.BlockActions = []*slack.BlockAction{{ActionID: "approve", BlockID: "request", Value: "42"}}
+	harness.SendInteraction(callback)
+
+	messages := harness.Messages()
+	if len(messages) != 1 {
+		t.Fatalf("expected 1 message, got %d", len(messages))
+	}
+
+	if messages[0].Channel != testChannelID || messages[0].Text != "approved 42" {
+		t.Errorf("unexpected message %+v", messages[0])
+	}
+}
+
+func TestSendReaction(t *testing.T) {
+	harness := slackertest.NewHarness()
+	defer harness.Close()
+
+	var reacted *slacker.ReactionMessage
+	harness.Bot().AddReaction(&slacker.ReactionDefinition{
+		Emoji: "ticket",
+		Handler: func(ctx *slacker.ReactionContext) {
+			reacted = ctx.Message()
+			ctx.Response().Reply("ticket created")
+		},
+	})
+
+	timeStamp := harness.SendMessage(testChannelID, testUserID, "the build is broken")
+	harness.SendReaction(testChannelID, "U456", timeStamp, "ticket")
+
+	if reacted == nil {
+		t.Fatal("expected the reaction to be handled")
+	}
+	if reacted.Text != "the build is broken" || reacted.UserID != testUserID || reacted.TimeStamp != timeStamp {
+		t.Errorf("unexpected reacted message %+v", reacted)
+	}
+
+	messages := harness.Messages()
+	if len(messages) != 1 || messages[0].Text != "ticket created" {
+		t.Fatalf("unexpected messages %+v", messages)
+	}
+}
+
+func TestSendReactionToThreadReply(t *testing.T) {
+	harness := slackertest.NewHarness()
+	defer harness.Close()
+
+	var reacted *slacker.ReactionMessage
+	harness.Bot().AddReaction(&slacker.ReactionDefinition{
+		Emoji: "eyes",
+		Handler: func(ctx *slacker.ReactionContext) {
+			reacted = ctx.Message()
+		},
+	})
+
+	parent := harness.SendMessage(testChannelID, testUserID, "deploying")
+	harness.SendThreadMessage(testChannelID, testUserID, parent, "step 1 done")
+	reply := harness.SendThreadMessage(testChannelID, testUserID, parent, "step 2 failed")
+	harness.SendThreadMessage(testChannelID, testUserID, parent, "retrying")
+	harness.SendReaction(testChannelID, "U456", reply, "eyes")
+
+	if reacted == nil {
+		t.Fatal("expected the reaction to be handled")
+	}
+	if reacted.Text != "step 2 failed" || reacted.TimeStamp != reply || reacted.ThreadTimeStamp != parent || !reacted.InThread() {
+		t.Errorf("unexpected reacted message %+v", reacted)
+	}
+}
+
+func TestRunJob(t *testing.T) {
+	harness := slackertest.NewHarness()
+	defer harness.Close()
+
+	harness.Bot().AddJob(&slacker.JobDefinition{
+		Name:           "digest",
+		CronExpression: "0 9 * * *",
+		Handler: func(ctx *slacker.JobContext) {
+			ctx.Response().Post(testChannelID, "daily digest")
+		},
+	})
+
+	if err := harness.RunJob("digest"); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	messages := harness.Messages()
+	if len(messages) != 1 || messages[0].Channel != testChannelID || messages[0].Text != "daily digest" {
+		t.Errorf("expected the job to post once it returned, got %+v", messages)
+	}
+
+	if err := harness.RunJob("unknown"); err == nil {
+		t.Error("expected an error running an unknown job")
+	}
+}
+
+func TestServerFailsInvalidRequests(t *testing.T) {
+	server := slackertest.NewServer()
+	defer server.Close()
+
+	client := slack.New("xoxb-test", slack.OptionAPIURL(server.URL()))
+	if _, _, err := client.PostMessage(testChannelID, slack.MsgOptionAttachments(slack.Attachment{Text: "valid"})); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(server.Errors()) > 0 {
+		t.Fatalf("unexpected errors %v", server.Errors())
+	}
+
+	response, err := http.PostForm(server.URL()+"chat.postMessage", url.Values{"channel": {testChannelID}, "attachments": {"not json"}})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	defer response.Body.Close()
+
+	var body struct {
+		Ok bool `json:"ok"`
+	}
+	if err := json.NewDecoder(response.Body).Decode(&body); err != nil || body.Ok {
+		t.Errorf("expected the request to fail, got %+v %v", body, err)
+	}
+	if len(server.Errors()) != 1 || len(server.Messages()) != 1 {
+		t.Errorf("expected the error to be recorded and the message not to be, got %v %+v", server.Errors(), server.Messages())
+	}
+}
+
+func TestExecuteFunction(t *testing.T) {
+	harness := slackertest.NewHarness()
+	defer harness.Close()
+
+	harness.Bot().AddFunction(&slacker.FunctionDefinition{
+		CallbackID: "double",
+		Handler: func(ctx *slacker.FunctionContext) {
+			var inputs struct {
+				Number int `slacker:"number"`
+			}
+			if err := ctx.BindInputs(&inputs); err != nil {
+				ctx.Fail(err.Error())
+				return
+			}
+			ctx.Complete(map[string]any{"result": inputs.Number * 2})
+		},
+	})
+	harness.Bot().AddFunction(&slacker.FunctionDefinition{
+		CallbackID: "broken",
+		Handler: slacker.FunctionHandlerWithError(func(ctx *slacker.FunctionContext) error {
+			return errors.New("broken")
+		}),
+	})
+
+	result, ok := harness.Server().FunctionResult(harness.ExecuteFunction("double", map[string]any{"number": 21}))
+	if !ok || result.IsError() {
+		t.Fatalf("expected a successful result, got %+v", result)
+	}
+	if result.Outputs["result"] != float64(42) {
+		t.Errorf("expected result 42, got %v", result.Outputs["result"])
+	}
+	if result.Token != slackertest.TestFunctionToken {
+		t.Errorf("expected the step to be completed with the token of the run, got %q", result.Token)
+	}
+
+	result, ok = harness.Server().FunctionResult(harness.ExecuteFunction("broken", nil))
+	if !ok || !result.IsError() || len(result.Error) == 0 {
+		t.Errorf("expected a failed result, got %+v", result)
+	}
+}
@@ -0,0 +1,525 @@
+package slackertest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/slack-go/slack"
+)
+
+const (
+	// TestAppID is the App ID reported by the fake Slack API
+	TestAppID = "A0TEST"
+
+	// TestBotID is the Bot ID reported by the fake Slack API
+	TestBotID = "B0TEST"
+
+	// TestTeamID is the Team ID reported by the fake Slack API
+	TestTeamID = "T0TEST"
+
+	// TestTriggerID is the trigger ID of the slash commands sent by the harness
+	TestTriggerID = "0000000000.trigger"
+
+	// TestFunctionToken is the token of the function runs sent by the harness
+	TestFunctionToken = "xwfp-test"
+
+	timestampFormat   = "1700000000.%06d"
+	permalinkFormat   = "https://slackertest.slack.com/archives/%s/p%s"
+	responseURLMethod = "response_url"
+)
+
+// Call contains a request received by the fake Slack API
+type Call struct {
+	// Method is the Slack API method that was called. For instance, `chat.postMessage`
+	Method string
+
+	// Values are the form values sent along with the request
+	Values url.Values
+}
+
+// Message contains a message that was posted, updated, scheduled or deleted
+// through the fake Slack API
+type Message struct {
+	// Method is the Slack API method that was called. For instance, `chat.postMessage`
+	Method string
+
+	// Channel the message was sent to
+	Channel string
+
+	// Text of the message
+	Text string
+
+	// Blocks of the message
+	Blocks []slack.Block
+
+	// Attachments of the message
+	Attachments []slack.Attachment
+
+	// ThreadTimeStamp is set when the message was sent inside a thread
+	ThreadTimeStamp string
+
+	// TimeStamp of the message. For updates and deletes, this is the timestamp
+	// of the original message.
+	TimeStamp string
+
+	// EphemeralUserID is set when the message is only visible to a single user
+	EphemeralUserID string
+
+	// PostAt is set when the message was scheduled
+	PostAt string
+
+	// ReplaceOriginal is set when the message replaced the last response sent
+	// through a response URL
+	ReplaceOriginal bool
+
+	// DeleteOriginal is set when the message deleted the last response sent
+	// through a response URL
+	DeleteOriginal bool
+}
+
+// IsEphemeral indicates if the message is only visible to a single user
+func (m *Message) IsEphemeral() bool {
+	return len(m.EphemeralUserID) > 0
+}
+
+// IsDelete indicates if the message was deleted
+func (m *Message) IsDelete() bool {
+	return m.Method == "chat.delete" || m.DeleteOriginal
+}
+
+// IsUpdate indicates if the message replaced an existing message
+func (m *Message) IsUpdate() bool {
+	return m.Method == "chat.update" || m.ReplaceOriginal
+}
+
+// FunctionResult contains how a run of a Workflow Builder custom step completed
+type FunctionResult struct {
+	// Method is either `functions.completeSuccess` or `functions.completeError`
+	Method string
+
+	// Outputs are the outputs of the step completed successfully
+	Outputs map[string]any
+
+	// Error is the message of the step that failed
+	Error string
+
+	// Token is the token the step was completed with
+	Token string
+}
+
+// IsError indicates if the step failed
+func (r *FunctionResult) IsError() bool {
+	return r.Method == "functions.completeError"
+}
+
+// NewServer starts a fake Slack API server that records every call it receives
+func NewServer() *Server {
+	server := &Server{
+		users:    make(map[string]slack.User),
+		channels: make(map[string]slack.Channel),
+		history:  make(map[string][]slack.Message),
+		homes:    make(map[string]slack.HomeTabViewRequest),
+		results:  make(map[string]*FunctionResult),
+	}
+	server.server = httptest.NewServer(http.HandlerFunc(server.handle))
+	return server
+}
+
+// Server is a fake Slack API that can be used with slacker.WithAPIURL
+type Server struct {
+	server   *httptest.Server
+	mutex    sync.Mutex
+	calls    []*Call
+	messages []*Message
+	users    map[string]slack.User
+	channels map[string]slack.Channel
+	history  map[string][]slack.Message
+	homes    map[string]slack.HomeTabViewRequest
+	modals   []slack.ModalViewRequest
+	results  map[string]*FunctionResult
+	errs     []error
+	counter  int
+}
+
+// URL returns the API URL of the server
+func (s *Server) URL() string {
+	return s.server.URL + "/"
+}
+
+// ResponseURL returns the response URL of slash commands sent in the channel
+// by the user. Responses are recorded with the `response_url` method.
+func (s *Server) ResponseURL(channelID string, userID string) string {
+	return s.URL() + responseURLMethod + "/" + channelID + "/" + userID
+}
+
+// Close shuts down the server
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// AddUser registers a user returned by `users.info`
+func (s *Server) AddUser(user slack.User) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.users[user.ID] = user
+}
+
+// AddChannel registers a channel returned by `conversations.info`
+func (s *Server) AddChannel(channel slack.Channel) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.channels[channel.ID] = channel
+}
+
+// AddMessage registers a message of the channel returned by `conversations.history`
+// if it is top level, and by `conversations.replies` within its thread
+func (s *Server) AddMessage(channelID string, message slack.Message) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	message.Channel = channelID
+	s.history[channelID] = append(s.history[channelID], message)
+}
+
+// HomeView returns the App Home view last published for the user with `views.publish`
+func (s *Server) HomeView(userID string) (slack.HomeTabViewRequest, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	view, ok := s.homes[userID]
+	return view, ok
+}
+
+// FunctionResult returns how the run of a custom step completed, if it did
+func (s *Server) FunctionResult(functionExecutionID string) (*FunctionResult, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	result, ok := s.results[functionExecutionID]
+	return result, ok
+}
+
+// Modals returns the modal views opened, pushed or updated so far
+func (s *Server) Modals() []slack.ModalViewRequest {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return append([]slack.ModalViewRequest{}, s.modals...)
+}
+
+// Calls returns every call received so far
+func (s *Server) Calls() []*Call {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return append([]*Call{}, s.calls...)
+}
+
+// Messages returns every message posted, updated, scheduled or deleted so far
+func (s *Server) Messages() []*Message {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return append([]*Message{}, s.messages...)
+}
+
+// Errors returns why the requests the server was unable to decode or answer
+// failed. Such requests are answered with an `invalid_arguments` error, tests
+// usually expect none.
+func (s *Server) Errors() []error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return append([]error{}, s.errs...)
+}
+
+// Reset forgets the recorded calls, messages, modals and errors
+func (s *Server) Reset() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.calls = nil
+	s.messages = nil
+	s.modals = nil
+	s.errs = nil
+}
+
+// NextTimeStamp generates a unique message timestamp
+func (s *Server) NextTimeStamp() string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.nextTimeStamp()
+}
+
+func (s *Server) nextTimeStamp() string {
+	s.counter++
+	return fmt.Sprintf(timestampFormat, s.counter)
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	method := strings.TrimPrefix(r.URL.Path, "/")
+
+	s.mutex.Lock()
+	var err error
+	if strings.HasPrefix(method, responseURLMethod+"/") {
+		err = s.respondURL(r, strings.TrimPrefix(method, responseURLMethod+"/"))
+		method = responseURLMethod
+	}
+	s.calls = append(s.calls, &Call{Method: method, Values: r.Form})
+	switch method {
+	case "views.publish":
+		err = s.publish(r)
+	case "views.open", "views.push", "views.update":
+		err = s.modal(r)
+	case "functions.completeSuccess", "functions.completeError":
+		err = s.complete(r, method)
+	}
+
+	var response map[string]any
+	if err == nil {
+		response, err = s.respond(method, r.Form)
+	}
+	if err != nil {
+		s.errs = append(s.errs, fmt.Errorf("%s: %w", method, err))
+		response = map[string]any{"ok": false, "error": "invalid_arguments"}
+	}
+	s.mutex.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		s.mutex.Lock()
+		s.errs = append(s.errs, fmt.Errorf("%s: %w", method, err))
+		s.mutex.Unlock()
+	}
+}
+
+func (s *Server) respond(method string, values url.Values) (map[string]any, error) {
+	switch method {
+	case "chat.postMessage", "chat.postEphemeral", "chat.update", "chat.delete", "chat.scheduleMessage":
+		message, err := s.record(method, values)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{
+			"ok":                   true,
+			"channel":              message.Channel,
+			"ts":                   message.TimeStamp,
+			"message_ts":           message.TimeStamp,
+			"scheduled_message_id": message.TimeStamp,
+			"text":                 message.Text,
+		}, nil
+	case "conversations.info":
+		channelID := values.Get("channel")
+		channel, ok := s.channels[channelID]
+		if !ok {
+			channel = slack.Channel{}
+			channel.ID = channelID
+			channel.Name = channelID
+		}
+		return map[string]any{"ok": true, "channel": channel}, nil
+	case "users.info":
+		userID := values.Get("user")
+		user, ok := s.users[userID]
+		if !ok {
+			user = slack.User{ID: userID, Name: userID, TeamID: TestTeamID}
+			user.Profile.DisplayName = userID
+			user.Profile.RealName = userID
+		}
+		return map[string]any{"ok": true, "user": user}, nil
+	case "conversations.history":
+		return map[string]any{"ok": true, "messages": s.findMessages(values)}, nil
+	case "conversations.replies":
+		return map[string]any{"ok": true, "messages": s.findReplies(values)}, nil
+	case "chat.getPermalink":
+		permalink := fmt.Sprintf(permalinkFormat, values.Get("channel"), strings.ReplaceAll(values.Get("message_ts"), ".", ""))
+		return map[string]any{"ok": true, "channel": values.Get("channel"), "permalink": permalink}, nil
+	case "bots.info":
+		return map[string]any{"ok": true, "bot": map[string]any{"id": values.Get("bot"), "app_id": TestAppID}}, nil
+	case "auth.test":
+		return map[string]any{"ok": true, "team_id": TestTeamID, "bot_id": TestBotID}, nil
+	default:
+		return map[string]any{"ok": true}, nil
+	}
+}
+
+// publish records the home view of a `views.publish` request, sent as JSON
+func (s *Server) publish(r *http.Request) error {
+	var request struct {
+		UserID string                   `json:"user_id"`
+		View   slack.HomeTabViewRequest `json:"view"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		return err
+	}
+	s.homes[request.UserID] = request.View
+	return nil
+}
+
+// respondURL records the message of a request to a response URL, sent as JSON
+func (s *Server) respondURL(r *http.Request, path string) error {
+	var response slack.Msg
+	if err := json.NewDecoder(r.Body).Decode(&response); err != nil {
+		return err
+	}
+
+	channelID, userID, _ := strings.Cut(path, "/")
+	message := &Message{
+		Method:          responseURLMethod,
+		Channel:         channelID,
+		Text:            response.Text,
+		Blocks:          response.Blocks.BlockSet,
+		Attachments:     response.Attachments,
+		TimeStamp:       s.nextTimeStamp(),
+		ReplaceOriginal: response.ReplaceOriginal,
+		DeleteOriginal:  response.DeleteOriginal,
+	}
+	if response.ResponseType != slack.ResponseTypeInChannel && !response.DeleteOriginal {
+		message.EphemeralUserID = userID
+	}
+	s.messages = append(s.messages, message)
+	return nil
+}
+
+// complete records the result of a `functions.completeSuccess` or `functions.completeError` request, sent as JSON
+func (s *Server) complete(r *http.Request, method string) error {
+	var request struct {
+		FunctionExecutionID string         `json:"function_execution_id"`
+		Outputs             map[string]any `json:"outputs"`
+		Error               string         `json:"error"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		return err
+	}
+	s.results[request.FunctionExecutionID] = &FunctionResult{
+		Method:  method,
+		Outputs: request.Outputs,
+		Error:   request.Error,
+		Token:   strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "),
+	}
+	return nil
+}
+
+// modal records the modal view of a `views.open`, `views.push` or `views.update` request, sent as JSON
+func (s *Server) modal(r *http.Request) error {
+	var request struct {
+		View slack.ModalViewRequest `json:"view"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		return err
+	}
+	s.modals = append(s.modals, request.View)
+	return nil
+}
+
+// record records the message of a request, failing if its blocks or attachments are invalid
+func (s *Server) record(method string, values url.Values) (*Message, error) {
+	message := &Message{
+		Method:          method,
+		Channel:         values.Get("channel"),
+		Text:            values.Get("text"),
+		ThreadTimeStamp: values.Get("thread_ts"),
+		TimeStamp:       values.Get("ts"),
+		EphemeralUserID: values.Get("user"),
+		PostAt:          values.Get("post_at"),
+	}
+
+	if blocks := values.Get("blocks"); len(blocks) > 0 {
+		var parsed slack.Blocks
+		if err := json.Unmarshal([]byte(blocks), &parsed); err != nil {
+			return nil, err
+		}
+		message.Blocks = parsed.BlockSet
+	}
+
+	if attachments := values.Get("attachments"); len(attachments) > 0 {
+		if err := json.Unmarshal([]byte(attachments), &message.Attachments); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(message.TimeStamp) == 0 {
+		message.TimeStamp = s.nextTimeStamp()
+	}
+
+	s.messages = append(s.messages, message)
+
+	if method == "chat.postMessage" {
+		s.history[message.Channel] = append(s.history[message.Channel], slack.Message{Msg: slack.Msg{
+			Channel:         message.Channel,
+			BotID:           TestBotID,
+			Text:            message.Text,
+			Timestamp:       message.TimeStamp,
+			ThreadTimestamp: message.ThreadTimeStamp,
+		}})
+	}
+	return message, nil
+}
+
+// findMessages returns the top level messages of the channel with the requested timestamp
+func (s *Server) findMessages(values url.Values) []slack.Message {
+	messages := []slack.Message{}
+	for _, message := range s.history[values.Get("channel")] {
+		if message.Timestamp != values.Get("latest") || isReply(message) {
+			continue
+		}
+		messages = append(messages, message)
+	}
+	return messages
+}
+
+// findReplies returns the messages of the thread containing the message with
+// the requested timestamp, parent first, within the requested time range
+func (s *Server) findReplies(values url.Values) []slack.Message {
+	threadTimeStamp := ""
+	for _, message := range s.history[values.Get("channel")] {
+		if message.Timestamp == values.Get("ts") {
+			threadTimeStamp = message.ThreadTimestamp
+			if len(threadTimeStamp) == 0 {
+				threadTimeStamp = message.Timestamp
+			}
+		}
+	}
+
+	limit, _ := strconv.Atoi(values.Get("limit"))
+	oldest, latest := values.Get("oldest"), values.Get("latest")
+
+	messages := []slack.Message{}
+	for _, message := range s.history[values.Get("channel")] {
+		if len(threadTimeStamp) == 0 || (message.Timestamp != threadTimeStamp && message.ThreadTimestamp != threadTimeStamp) {
+			continue
+		}
+
+		if (len(oldest) > 0 && message.Timestamp < oldest) || (len(latest) > 0 && message.Timestamp > latest) {
+			continue
+		}
+
+		if message.ThreadTimestamp == "" {
+			// The parent of a thread carries its own timestamp as thread timestamp
+			message.ThreadTimestamp = message.Timestamp
+		}
+
+		messages = append(messages, message)
+		if limit > 0 && len(messages) == limit {
+			break
+		}
+	}
+	return messages
+}
+
+func isReply(message slack.Message) bool {
+	return len(message.ThreadTimestamp) > 0 && message.ThreadTimestamp != message.Timestamp
+}
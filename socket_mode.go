@@ -0,0 +1,157 @@
+package slacker
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/slack-go/slack"
+	"github.com/slack-go/slack/slackevents"
+	"github.com/slack-go/slack/socketmode"
+)
+
+// listenSocketMode receives events over Socket Mode and dispatches them
+func (s *Slacker) listenSocketMode(ctx context.Context) error {
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case socketEvent, ok := <-s.socketModeClient.Events:
+				if !ok {
+					return
+				}
+
+				switch socketEvent.Type {
+				case socketmode.EventTypeConnecting:
+					s.logger.Info("connecting to Slack with Socket Mode...")
+
+					if s.onConnecting == nil {
+						continue
+					}
+					go s.onConnecting(socketEvent)
+
+				case socketmode.EventTypeConnectionError:
+					s.logger.Info("connection failed. Retrying later...")
+
+					if s.onConnectionError == nil {
+						continue
+					}
+					go s.onConnectionError(socketEvent)
+
+				case socketmode.EventTypeConnected:
+					s.logger.Info("connected to Slack with Socket Mode.")
+
+					if s.onConnected == nil {
+						continue
+					}
+					go s.onConnected(socketEvent)
+
+				case socketmode.EventTypeHello:
+					s.appID = socketEvent.Request.ConnectionInfo.AppID
+					s.logger.Info("connected", "app_id", s.appID)
+
+					if s.onHello == nil {
+						continue
+					}
+					go s.onHello(socketEvent)
+
+				case socketmode.EventTypeDisconnect:
+					s.logger.Info("disconnected", "reason", socketEvent.Request.Reason)
+
+					if s.onDisconnected == nil {
+						continue
+					}
+					go s.onDisconnected(socketEvent)
+
+				case socketmode.EventTypeEventsAPI:
+					event, ok := socketEvent.Data.(slackevents.EventsAPIEvent)
+					if !ok {
+						s.logger.Debug("ignored event", "event", socketEvent)
+						continue
+					}
+
+					// Acknowledge receiving the request
+					s.socketModeClient.Ack(*socketEvent.Request)
+
+					s.dispatchEventsAPIEvent(socketEvent, event)
+
+				case socketmode.EventTypeSlashCommand:
+					event, ok := socketEvent.Data.(slack.SlashCommand)
+					if !ok {
+						s.logger.Debug("ignored event", "event", socketEvent)
+						continue
+					}
+
+					// Wait for the handler to acknowledge the request, possibly with a payload
+					s.dispatchSlashCommand(event, s.newSocketModeAcknowledger(*socketEvent.Request))
+
+				case socketmode.EventTypeInteractive:
+					callback, ok := socketEvent.Data.(slack.InteractionCallback)
+					if !ok {
+						s.logger.Debug("ignored event", "event", socketEvent)
+						continue
+					}
+
+					var acknowledger *acknowledger
+					if requiresAckPayload(&callback) {
+						acknowledger = s.newSocketModeAcknowledger(*socketEvent.Request)
+					} else {
+						// Acknowledge receiving the request
+						s.socketModeClient.Ack(*socketEvent.Request)
+					}
+
+					s.dispatchInteraction(callback, acknowledger)
+
+				case socketmode.EventTypeErrorBadMessage:
+					// slack-go fails to parse the events it does not know about, such as function events
+					s.handleSocketModeBadMessage(socketEvent)
+
+				default:
+					s.handleUnsupportedEvent(socketEvent)
+				}
+			}
+		}
+	}()
+
+	// blocking call that handles listening for events and placing them in the
+	// Events channel as well as handling outgoing events.
+	return s.socketModeClient.RunContext(ctx)
+}
+
+// newSocketModeAcknowledger creates an acknowledger of the socket mode request
+func (s *Slacker) newSocketModeAcknowledger(request socketmode.Request) *acknowledger {
+	return newAcknowledger(ackTimeout, func(payload any) {
+		if payload == nil {
+			s.socketModeClient.Ack(request)
+			return
+		}
+		s.socketModeClient.Ack(request, payload)
+	})
+}
+
+// handleSocketModeBadMessage dispatches the function events slack-go was unable
+// to parse, other bad messages are unsupported
+func (s *Slacker) handleSocketModeBadMessage(socketEvent socketmode.Event) {
+	badMessage, ok := socketEvent.Data.(*socketmode.ErrorBadMessage)
+	if !ok {
+		s.handleUnsupportedEvent(socketEvent)
+		return
+	}
+
+	request := &socketmode.Request{}
+	if err := json.Unmarshal(badMessage.Message, request); err != nil || request.Type != socketmode.RequestTypeEventsAPI {
+		s.handleUnsupportedEvent(socketEvent)
+		return
+	}
+
+	event, ok := parseFunctionEvent(request.Payload)
+	if !ok {
+		s.handleUnsupportedEvent(socketEvent)
+		return
+	}
+
+	// Acknowledge receiving the request
+	s.socketModeClient.Ack(*request)
+
+	s.dispatchEventsAPIEvent(socketmode.Event{Type: socketmode.EventTypeEventsAPI, Data: event, Request: request}, event)
+}
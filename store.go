@@ -0,0 +1,307 @@
+package slacker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const (
+	commandStoreNamespace     = "command:"
+	interactionStoreNamespace = "interaction:"
+	jobStoreNamespace         = "job:"
+	userStoreNamespace        = "user:"
+	conversationStoreKey      = "conversation:"
+	storeNamespaceSeparator   = ":"
+	fileStorePermissions      = 0o600
+	conversationGracePeriod   = time.Hour
+)
+
+// Store persists state shared by handlers. Implement it to plug in an external
+// store such as Redis or a database.
+type Store interface {
+	// Get returns the value stored for the key, if any and not expired
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+
+	// Set stores the value for the key, expiring after `ttl`. A zero ttl never expires.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+
+	// Delete removes the value stored for the key
+	Delete(ctx context.Context, key string) error
+}
+
+// storeEntry contains a stored value and its expiration
+type storeEntry struct {
+	Value     []byte     `json:"value"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// isExpired determines whether the entry expired at `now`
+func (e *storeEntry) isExpired(now time.Time) bool {
+	return e.ExpiresAt != nil && now.After(*e.ExpiresAt)
+}
+
+// newStoreEntry creates an entry expiring `ttl` after `now`, never if ttl is zero
+func newStoreEntry(value []byte, ttl time.Duration, now time.Time) *storeEntry {
+	entry := &storeEntry{Value: append([]byte{}, value...)}
+	if ttl > 0 {
+		expiresAt := now.Add(ttl)
+		entry.ExpiresAt = &expiresAt
+	}
+	return entry
+}
+
+// NewMemoryStore creates an in-memory store, lost when the bot stops
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		entries: make(map[string]*storeEntry),
+		clock:   time.Now,
+	}
+}
+
+// MemoryStore is an in-memory store with expiring entries
+type MemoryStore struct {
+	mutex   sync.Mutex
+	entries map[string]*storeEntry
+	clock   func() time.Time
+}
+
+// Get returns the value stored for the key, if any and not expired
+func (s *MemoryStore) Get(_ context.Context, key string) ([]byte, bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	entry, ok := s.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+
+	if entry.isExpired(s.clock()) {
+		delete(s.entries, key)
+		return nil, false, nil
+	}
+	return append([]byte{}, entry.Value...), true, nil
+}
+
+// Set stores the value for the key, expiring after `ttl`. A zero ttl never expires.
+func (s *MemoryStore) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.entries[key] = newStoreEntry(value, ttl, s.clock())
+	return nil
+}
+
+// Delete removes the value stored for the key
+func (s *MemoryStore) Delete(_ context.Context, key string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	delete(s.entries, key)
+	return nil
+}
+
+// NewFileStore creates a store persisted to a JSON file, loading the entries
+// the file already contains. The file is created on the first write.
+func NewFileStore(path string) (*FileStore, error) {
+	store := &FileStore{
+		path:    path,
+		entries: make(map[string]*storeEntry),
+		clock:   time.Now,
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return store, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &store.entries); err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+// FileStore is a store persisted to a JSON file, rewritten on every change.
+// It suits bots with little state running as a single instance.
+type FileStore struct {
+	path    string
+	mutex   sync.Mutex
+	entries map[string]*storeEntry
+	clock   func() time.Time
+}
+
+// Get returns the value stored for the key, if any and not expired
+func (s *FileStore) Get(_ context.Context, key string) ([]byte, bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	entry, ok := s.entries[key]
+	if !ok || entry.isExpired(s.clock()) {
+		return nil, false, nil
+	}
+	return append([]byte{}, entry.Value...), true, nil
+}
+
+// Set stores the value for the key, expiring after `ttl`. A zero ttl never expires.
+func (s *FileStore) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.entries[key] = newStoreEntry(value, ttl, s.clock())
+	return s.save()
+}
+
+// Delete removes the value stored for the key
+func (s *FileStore) Delete(_ context.Context, key string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.entries[key]; !ok {
+		return nil
+	}
+
+	delete(s.entries, key)
+	return s.save()
+}
+
+// save writes the entries not expired to a temporary file, then replaces the
+// store file with it so that a crash never leaves a partial file behind
+func (s *FileStore) save() error {
+	now := s.clock()
+	for key, entry := range s.entries {
+		if entry.isExpired(now) {
+			delete(s.entries, key)
+		}
+	}
+
+	data, err := json.Marshal(s.entries)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+
+	if err := file.Chmod(fileStorePermissions); err != nil {
+		file.Close()
+		return err
+	}
+
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), s.path)
+}
+
+// NewScopedStore creates a store prefixing every key with the namespace
+func NewScopedStore(store Store, namespace string) *ScopedStore {
+	return &ScopedStore{store: store, namespace: namespace + storeNamespaceSeparator}
+}
+
+// ScopedStore is a store namespaced per command, interaction, job or user,
+// so that keys of different handlers never collide
+type ScopedStore struct {
+	store     Store
+	namespace string
+}
+
+// Get returns the value stored for the key, if any and not expired
+func (s *ScopedStore) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	return s.store.Get(ctx, s.namespace+key)
+}
+
+// Set stores the value for the key, expiring after `ttl`. A zero ttl never expires.
+func (s *ScopedStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return s.store.Set(ctx, s.namespace+key, value, ttl)
+}
+
+// Delete removes the value stored for the key
+func (s *ScopedStore) Delete(ctx context.Context, key string) error {
+	return s.store.Delete(ctx, s.namespace+key)
+}
+
+// GetJSON decodes the value stored for the key into `value`, returning false if there is none
+func (s *ScopedStore) GetJSON(ctx context.Context, key string, value any) (bool, error) {
+	data, ok, err := s.Get(ctx, key)
+	if err != nil || !ok {
+		return false, err
+	}
+	return true, json.Unmarshal(data, value)
+}
+
+// SetJSON stores the value encoded as JSON for the key, expiring after `ttl`
+func (s *ScopedStore) SetJSON(ctx context.Context, key string, value any, ttl time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return s.Set(ctx, key, data, ttl)
+}
+
+// newUserStore creates the store of a user, shared by every handler
+func newUserStore(store Store, userID string) *ScopedStore {
+	return NewScopedStore(store, userStoreNamespace+userID)
+}
+
+// NewStoreConversationStore creates a conversation store persisting the state of
+// conversations as JSON in the store, for instance a FileStore so that
+// conversations survive restarts
+func NewStoreConversationStore(store Store) ConversationStore {
+	return &storeConversationStore{store: store, clock: time.Now}
+}
+
+// storeConversationStore adapts a Store into a ConversationStore
+type storeConversationStore struct {
+	store Store
+	clock func() time.Time
+}
+
+// Get returns the state stored for the key, if any
+func (s *storeConversationStore) Get(ctx context.Context, key string) (*ConversationState, bool, error) {
+	data, ok, err := s.store.Get(ctx, conversationStoreKey+key)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+
+	state := &ConversationState{}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, false, err
+	}
+	return state, true, nil
+}
+
+// Set stores the state for the key. It is kept for a while after the conversation
+// timed out, so that the user can be told about it.
+func (s *storeConversationStore) Set(ctx context.Context, key string, state *ConversationState) error {
+	ttl := state.ExpiresAt.Sub(s.clock()) + conversationGracePeriod
+	if ttl <= 0 {
+		return s.Delete(ctx, key)
+	}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return s.store.Set(ctx, conversationStoreKey+key, data, ttl)
+}
+
+// Delete removes the state stored for the key
+func (s *storeConversationStore) Delete(ctx context.Context, key string) error {
+	return s.store.Delete(ctx, conversationStoreKey+key)
+}
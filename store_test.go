@@ -0,0 +1,207 @@
+package slacker
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// testClock is a clock only moving forward when told to
+type testClock struct {
+	now time.Time
+}
+
+func newTestClock() *testClock {
+	return &testClock{now: time.Date(2023, time.March, 1, 12, 0, 0, 0, time.UTC)}
+}
+
+func (c *testClock) Now() time.Time {
+	return c.now
+}
+
+func (c *testClock) advance(duration time.Duration) {
+	c.now = c.now.Add(duration)
+}
+
+// expectStored checks the value stored for the key, missing if empty
+func expectStored(t *testing.T, store Store, key string, expected string) {
+	t.Helper()
+
+	value, ok, err := store.Get(context.Background(), key)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if ok != (len(expected) > 0) || string(value) != expected {
+		t.Errorf("expected %q to be stored for %s, got %q %t", expected, key, value, ok)
+	}
+}
+
+func TestMemoryStore(t *testing.T) {
+	ctx := context.Background()
+	clock := newTestClock()
+	store := NewMemoryStore()
+	store.clock = clock.Now
+
+	value := []byte("kept")
+	store.Set(ctx, "forever", value, 0)
+	store.Set(ctx, "short", []byte("expiring"), time.Minute)
+	store.Set(ctx, "deleted", []byte("deleted"), 0)
+	store.Delete(ctx, "deleted")
+
+	// The store keeps its own copy
+	value[0] = 'K'
+
+	clock.advance(time.Minute)
+	expectStored(t, store, "forever", "kept")
+	expectStored(t, store, "short", "expiring")
+	expectStored(t, store, "deleted", "")
+
+	clock.advance(time.Second)
+	expectStored(t, store, "short", "")
+	if _, ok := store.entries["short"]; ok {
+		t.Error("expected the expired entry to be removed")
+	}
+}
+
+func TestFileStore(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "store.json")
+	clock := newTestClock()
+
+	store, err := NewFileStore(path)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	store.clock = clock.Now
+
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("expected the file to be created on the first write, got %v", err)
+	}
+
+	store.Set(ctx, "forever", []byte("kept"), 0)
+	store.Set(ctx, "short", []byte("expiring"), time.Minute)
+	store.Set(ctx, "deleted", []byte("deleted"), 0)
+	store.Delete(ctx, "deleted")
+
+	// Saving drops the expired entries from the file
+	clock.advance(2 * time.Minute)
+	store.Set(ctx, "later", []byte("added"), time.Hour)
+
+	reloaded, err := NewFileStore(path)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	reloaded.clock = clock.Now
+
+	expectStored(t, reloaded, "forever", "kept")
+	expectStored(t, reloaded, "later", "added")
+	expectStored(t, reloaded, "deleted", "")
+	if _, ok := reloaded.entries["short"]; ok {
+		t.Error("expected the expired entry not to be saved")
+	}
+
+	// The expiration survives reloading
+	clock.advance(time.Hour + time.Second)
+	expectStored(t, reloaded, "later", "")
+
+	// The temporary file was renamed over the store file
+	files, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(files) != 1 || files[0].Name() != "store.json" {
+		t.Errorf("expected only the store file, got %v", files)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if info.Mode().Perm() != fileStorePermissions {
+		t.Errorf("expected permissions %o, got %o", fileStorePermissions, info.Mode().Perm())
+	}
+}
+
+func TestFileStoreInvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "store.json")
+	os.WriteFile(path, []byte("not json"), fileStorePermissions)
+
+	if _, err := NewFileStore(path); err == nil {
+		t.Error("expected an error loading an invalid file")
+	}
+}
+
+func TestScopedStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+
+	alice := newUserStore(store, "U123")
+	bob := newUserStore(store, "U456")
+
+	alice.Set(ctx, "color", []byte("blue"), 0)
+	bob.Set(ctx, "color", []byte("red"), 0)
+
+	expectStored(t, alice, "color", "blue")
+	expectStored(t, bob, "color", "red")
+	expectStored(t, store, "user:U123:color", "blue")
+
+	bob.Delete(ctx, "color")
+	expectStored(t, alice, "color", "blue")
+	expectStored(t, bob, "color", "")
+
+	type settings struct {
+		Theme string `json:"theme"`
+	}
+	if err := alice.SetJSON(ctx, "settings", &settings{Theme: "dark"}, 0); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	var decoded settings
+	if ok, err := alice.GetJSON(ctx, "settings", &decoded); !ok || err != nil || decoded.Theme != "dark" {
+		t.Errorf("expected the JSON value to be decoded, got %+v %t %v", decoded, ok, err)
+	}
+	if ok, err := bob.GetJSON(ctx, "settings", &decoded); ok || err != nil {
+		t.Errorf("expected no JSON value, got %t %v", ok, err)
+	}
+}
+
+func TestStoreConversationStore(t *testing.T) {
+	ctx := context.Background()
+	clock := newTestClock()
+	store := NewMemoryStore()
+	store.clock = clock.Now
+
+	conversations := NewStoreConversationStore(store).(*storeConversationStore)
+	conversations.clock = clock.Now
+
+	state := &ConversationState{Name: "survey", ExpiresAt: clock.Now().Add(10 * time.Minute)}
+	if err := conversations.Set(ctx, "C123:1234.5678", state); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	stored, ok, err := conversations.Get(ctx, "C123:1234.5678")
+	if err != nil || !ok || stored.Name != "survey" || !stored.ExpiresAt.Equal(state.ExpiresAt) {
+		t.Fatalf("expected the state to be stored, got %+v %t %v", stored, ok, err)
+	}
+
+	// Kept past its timeout for the grace period
+	entry := store.entries[conversationStoreKey+"C123:1234.5678"]
+	if expected := state.ExpiresAt.Add(conversationGracePeriod); entry == nil || !entry.ExpiresAt.Equal(expected) {
+		t.Errorf("expected the state to expire at %s, got %+v", expected, entry)
+	}
+
+	// States already past the grace period are deleted instead
+	clock.advance(10*time.Minute + conversationGracePeriod + time.Second)
+	if err := conversations.Set(ctx, "C123:1234.5678", state); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if _, ok := store.entries[conversationStoreKey+"C123:1234.5678"]; ok {
+		t.Error("expected the state to be deleted")
+	}
+
+	if _, ok, err := conversations.Get(ctx, "unknown"); ok || err != nil {
+		t.Errorf("expected no state, got %t %v", ok, err)
+	}
+}
@@ -0,0 +1,108 @@
+package slacker
+
+import (
+	"github.com/slack-go/slack"
+)
+
+// OptionsDefinition structure contains definition of the options of external
+// selects, suggested as the user types
+type OptionsDefinition struct {
+	// ActionID matches the action ID of the external select
+	ActionID string
+
+	// BlockID matches the block ID of the external select, any block if empty
+	BlockID string
+
+	// MatchMode determines how ActionID and BlockID are matched, exactly by default
+	MatchMode MatchMode
+
+	Middlewares []OptionsMiddlewareHandler
+
+	// Handler suggests the options matching the query by calling
+	// OptionsContext.Respond or OptionsContext.RespondGroups. It must return
+	// within the 3 seconds Slack waits for the options.
+	Handler OptionsHandler
+}
+
+// newOptions creates a new bot options object. It fails if the IDs are not
+// valid regular expressions with MatchRegex.
+func newOptions(definition *OptionsDefinition) (*Options, error) {
+	actionMatcher, err := newIDMatcher(definition.MatchMode, definition.ActionID)
+	if err != nil {
+		return nil, err
+	}
+
+	blockMatcher, err := newIDMatcher(definition.MatchMode, definition.BlockID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Options{
+		definition:    definition,
+		actionMatcher: actionMatcher,
+		blockMatcher:  blockMatcher,
+	}, nil
+}
+
+// Options structure contains the bot's options of external selects and their handler
+type Options struct {
+	definition    *OptionsDefinition
+	actionMatcher *idMatcher
+	blockMatcher  *idMatcher
+}
+
+// Definition returns the options definition
+func (o *Options) Definition() *OptionsDefinition {
+	return o.definition
+}
+
+// Match determines whether the options are suggested for the callback,
+// returning the captures of the match
+func (o *Options) Match(callback *slack.InteractionCallback) (*InteractionMatch, bool) {
+	match := newInteractionMatch(nil)
+	if !o.blockMatcher.match(callback.BlockID, match) {
+		return nil, false
+	}
+
+	if !o.actionMatcher.match(callback.ActionID, match) {
+		return nil, false
+	}
+	return match, true
+}
+
+// OptionsQuery contains what the user typed in an external select
+type OptionsQuery struct {
+	// Value is the text typed by the user, possibly empty
+	Value string
+
+	// ActionID is the action ID of the external select
+	ActionID string
+
+	// BlockID is the block ID of the external select
+	BlockID string
+
+	// UserID is the user typing
+	UserID string
+
+	// ChannelID is the channel of the message holding the select, empty in views
+	ChannelID string
+
+	// CallbackID is the callback ID of the view holding the select, empty in messages
+	CallbackID string
+
+	// PrivateMetadata is the private metadata of the view holding the select
+	PrivateMetadata string
+}
+
+// newOptionsQuery creates a new options query from the callback
+func newOptionsQuery(callback *slack.InteractionCallback) *OptionsQuery {
+	return &OptionsQuery{
+		Value:           callback.Value,
+		ActionID:        callback.ActionID,
+		BlockID:         callback.BlockID,
+		UserID:          callback.User.ID,
+		ChannelID:       callback.Channel.ID,
+		CallbackID:      callback.View.CallbackID,
+		PrivateMetadata: callback.View.PrivateMetadata,
+	}
+}
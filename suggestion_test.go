@@ -0,0 +1,150 @@
+package slacker_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/shomali11/slacker/v2"
+	"github.com/shomali11/slacker/v2/slackertest"
+	"github.com/slack-go/slack"
+)
+
+func newBlockSuggestionCallback(blockID string, actionID string, value string) *slack.InteractionCallback {
+	callback := &slack.InteractionCallback{
+		Type:     slack.InteractionTypeBlockSuggestion,
+		BlockID:  blockID,
+		ActionID: actionID,
+		Value:    value,
+	}
+	callback.User.ID = "U123"
+	callback.View.CallbackID = "assign"
+	return callback
+}
+
+func newOption(value string) *slack.OptionBlockObject {
+	return slack.NewOptionBlockObject(value, slack.NewTextBlockObject(slack.PlainTextType, value, false, false), nil)
+}
+
+func TestOptionsMatching(t *testing.T) {
+	tests := []struct {
+		name       string
+		definition slacker.OptionsDefinition
+		blockID    string
+		actionID   string
+		matched    bool
+		captures   []string
+	}{
+		{
+			name:       "exact action",
+			definition: slacker.OptionsDefinition{ActionID: "assignee"},
+			blockID:    "ticket",
+			actionID:   "assignee",
+			matched:    true,
+			captures:   []string{},
+		},
+		{
+			name:       "exact action mismatch",
+			definition: slacker.OptionsDefinition{ActionID: "assignee"},
+			actionID:   "assignee-2",
+		},
+		{
+			name:       "block mismatch",
+			definition: slacker.OptionsDefinition{BlockID: "ticket", ActionID: "assignee"},
+			blockID:    "other",
+			actionID:   "assignee",
+		},
+		{
+			name:       "prefix",
+			definition: slacker.OptionsDefinition{ActionID: "assignee-", MatchMode: slacker.MatchPrefix},
+			actionID:   "assignee-2",
+			matched:    true,
+			captures:   []string{"2"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			harness := slackertest.NewHarness()
+			defer harness.Close()
+
+			var query *slacker.OptionsQuery
+			var captures []string
+			definition := test.definition
+			definition.Handler = func(ctx *slacker.OptionsContext) {
+				query, captures = ctx.Query(), ctx.Captures()
+				ctx.Respond(newOption("alice"))
+			}
+			harness.Bot().AddOptions(&definition)
+
+			payload := harness.SubmitInteraction(newBlockSuggestionCallback(test.blockID, test.actionID, "al"))
+
+			if matched := query != nil; matched != test.matched {
+				t.Fatalf("expected matched %t, got %t", test.matched, matched)
+			}
+			if !test.matched {
+				return
+			}
+
+			if query.Value != "al" || query.ActionID != test.actionID || query.CallbackID != "assign" {
+				t.Errorf("expected the query of the user, got %+v", query)
+			}
+			if !reflect.DeepEqual(captures, test.captures) {
+				t.Errorf("expected captures %q, got %q", test.captures, captures)
+			}
+
+			response, ok := payload.(*slack.OptionsResponse)
+			if !ok || len(response.Options) != 1 || response.Options[0].Value != "alice" {
+				t.Errorf("expected the options to be acknowledged, got %+v", payload)
+			}
+		})
+	}
+}
+
+func TestOptionsRespondGroups(t *testing.T) {
+	harness := slackertest.NewHarness()
+	defer harness.Close()
+
+	harness.Bot().AddOptions(&slacker.OptionsDefinition{
+		ActionID: "assignee",
+		Handler: func(ctx *slacker.OptionsContext) {
+			ctx.Respond(newOption("bob"))
+			ctx.RespondGroups(slack.NewOptionGroupBlockElement(
+				slack.NewTextBlockObject(slack.PlainTextType, "Team", false, false),
+				newOption("alice"),
+			))
+		},
+	})
+
+	payload := harness.SubmitInteraction(newBlockSuggestionCallback("", "assignee", ""))
+
+	response, ok := payload.(*slack.OptionGroupsResponse)
+	if !ok || len(response.OptionGroups) != 1 || response.OptionGroups[0].Options[0].Value != "alice" {
+		t.Errorf("expected the option groups to replace the options, got %+v", payload)
+	}
+}
+
+func TestOptionsNoMatchFallsThrough(t *testing.T) {
+	harness := slackertest.NewHarness()
+	defer harness.Close()
+
+	harness.Bot().AddOptions(&slacker.OptionsDefinition{
+		ActionID: "assignee",
+		Handler: func(ctx *slacker.OptionsContext) {
+			ctx.Respond(newOption("alice"))
+		},
+	})
+
+	var interacted bool
+	harness.Bot().UnsupportedInteractionHandler(func(*slacker.InteractionContext) {
+		interacted = true
+	})
+
+	payload := harness.SubmitInteraction(newBlockSuggestionCallback("", "reviewer", ""))
+
+	if payload != nil {
+		t.Errorf("expected no options to be acknowledged, got %+v", payload)
+	}
+	if !interacted {
+		t.Error("expected the block suggestion to fall through to the unsupported interaction handler")
+	}
+}
@@ -0,0 +1,141 @@
+package slacker
+
+import (
+	"context"
+	"sync"
+)
+
+const (
+	spanHandler       = "slacker.handler"
+	spanMiddleware    = "slacker.middleware"
+	spanInvoke        = "slacker.handler.invoke"
+	spanMatch         = "slacker.match"
+	spanLookupChannel = "slacker.lookup.channel"
+	spanLookupUser    = "slacker.lookup.user"
+	spanPostMessage   = "slack.chat.postMessage"
+	spanDeleteMessage = "slack.chat.delete"
+	attributeIndex    = "middleware.index"
+	attributeMatched  = "matched"
+	attributeCacheHit = "cache.hit"
+)
+
+// Tracer starts spans. Implement it to export traces to OpenTelemetry or any
+// other tracing system, returning a context carrying the started span.
+type Tracer interface {
+	Start(ctx context.Context, name string, attributes ...Attribute) (context.Context, Span)
+}
+
+// Span represents a unit of work within a trace
+type Span interface {
+	// SetAttributes adds attributes to the span
+	SetAttributes(attributes ...Attribute)
+
+	// RecordError records an error on the span
+	RecordError(err error)
+
+	// End completes the span
+	End()
+}
+
+// Attribute is a key/value pair describing a span
+type Attribute struct {
+	Key   string
+	Value any
+}
+
+// Attr creates a span attribute
+func Attr(key string, value any) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// StartSpan starts a span, child of the span in the context, using the tracer
+// of the event being handled. Without tracer, the span does nothing.
+func StartSpan(ctx context.Context, name string, attributes ...Attribute) (context.Context, Span) {
+	tracer, ok := ctx.Value(tracerContextKey{}).(Tracer)
+	if !ok {
+		return ctx, noopSpan{}
+	}
+	return tracer.Start(ctx, name, attributes...)
+}
+
+// isTraced returns true if the context carries a tracer
+func isTraced(ctx context.Context) bool {
+	_, ok := ctx.Value(tracerContextKey{}).(Tracer)
+	return ok
+}
+
+// tracerContextKey is the context key of the tracer
+type tracerContextKey struct{}
+
+// noopSpan is the span started without tracer
+type noopSpan struct{}
+
+func (noopSpan) SetAttributes(...Attribute) {}
+
+func (noopSpan) RecordError(error) {}
+
+func (noopSpan) End() {}
+
+// traceRoot runs the handler within the root span of an event
+func (s *Slacker) traceRoot(name string, handler func(ctx context.Context)) func(ctx context.Context) {
+	if s.tracer == nil {
+		return handler
+	}
+
+	return func(ctx context.Context) {
+		ctx = context.WithValue(ctx, tracerContextKey{}, s.tracer)
+		ctx, span := s.tracer.Start(ctx, name)
+		defer span.End()
+
+		handler(ctx)
+	}
+}
+
+// endSpan ends the span, recording the error of the handler if any
+func endSpan(span Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+	}
+	span.End()
+}
+
+// newSpanChain creates a new span chain structure, starting from the span in ctx
+func newSpanChain(ctx context.Context) *spanChain {
+	return &spanChain{ctx: ctx}
+}
+
+// spanChain nests the spans of the middlewares and of the handler they wrap,
+// each a child of the span of the one calling it. The handler context is left
+// untouched, it carries the span of the handler it was created with.
+type spanChain struct {
+	mutex sync.Mutex
+	ctx   context.Context
+}
+
+// enter starts a span, child of the current one, and makes it current until
+// the returned function is called
+func (c *spanChain) enter(name string, attributes ...Attribute) (Span, func()) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	parent := c.ctx
+	ctx, span := StartSpan(parent, name, attributes...)
+	c.ctx = ctx
+
+	return span, func() {
+		c.mutex.Lock()
+		c.ctx = parent
+		c.mutex.Unlock()
+	}
+}
+
+// traceChained runs the handler within a span of the chain
+func traceChained[H ~func(C), C any](chain *spanChain, handler H, name string, attributes ...Attribute) H {
+	return func(handlerCtx C) {
+		span, leave := chain.enter(name, attributes...)
+		defer span.End()
+		defer leave()
+
+		handler(handlerCtx)
+	}
+}
@@ -0,0 +1,143 @@
+package slacker_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/shomali11/slacker/v2"
+	"github.com/shomali11/slacker/v2/slackertest"
+)
+
+// recordedSpan is a span started by the recording tracer
+type recordedSpan struct {
+	name   string
+	parent *recordedSpan
+	ended  bool
+}
+
+func (s *recordedSpan) SetAttributes(...slacker.Attribute) {}
+
+func (s *recordedSpan) RecordError(error) {}
+
+func (s *recordedSpan) End() { s.ended = true }
+
+type spanContextKey struct{}
+
+// recordingTracer records the spans it starts, and their parent
+type recordingTracer struct {
+	mutex sync.Mutex
+	spans []*recordedSpan
+}
+
+func (t *recordingTracer) Start(ctx context.Context, name string, _ ...slacker.Attribute) (context.Context, slacker.Span) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	parent, _ := ctx.Value(spanContextKey{}).(*recordedSpan)
+	span := &recordedSpan{name: name, parent: parent}
+	t.spans = append(t.spans, span)
+	return context.WithValue(ctx, spanContextKey{}, span), span
+}
+
+func (t *recordingTracer) find(name string) *recordedSpan {
+	spans := t.findAll(name)
+	if len(spans) == 0 {
+		return nil
+	}
+	return spans[0]
+}
+
+// findAll returns the spans with the name, in the order they started
+func (t *recordingTracer) findAll(name string) []*recordedSpan {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	spans := []*recordedSpan{}
+	for _, span := range t.spans {
+		if span.name == name {
+			spans = append(spans, span)
+		}
+	}
+	return spans
+}
+
+func TestHandlerContextCarriesHandlerSpan(t *testing.T) {
+	tracer := &recordingTracer{}
+	harness := slackertest.NewHarness(slacker.WithTracer(tracer))
+	defer harness.Close()
+
+	var handlerCtx context.Context
+	harness.Bot().AddCommand(&slacker.CommandDefinition{
+		Command: "ping",
+		Middlewares: []slacker.CommandMiddlewareHandler{
+			func(next slacker.CommandHandler) slacker.CommandHandler {
+				return next
+			},
+		},
+		Handler: func(ctx *slacker.CommandContext) {
+			handlerCtx = ctx.Context()
+			ctx.Response().Reply("pong")
+		},
+	})
+
+	harness.SendMessage("C123", "U123", "ping")
+
+	handlerSpan := tracer.find("slacker.handler")
+	if handlerSpan == nil || !handlerSpan.ended {
+		t.Fatalf("expected an ended handler span, got %+v", handlerSpan)
+	}
+
+	middlewareSpan := tracer.find("slacker.middleware")
+	if middlewareSpan == nil || middlewareSpan.parent != handlerSpan {
+		t.Errorf("expected the middleware span to be a child of the handler span, got %+v", middlewareSpan)
+	}
+
+	postSpan := tracer.find("slack.chat.postMessage")
+	if postSpan == nil || postSpan.parent != handlerSpan {
+		t.Errorf("expected the reply span to be a child of the handler span, got %+v", postSpan)
+	}
+
+	// The context outlives the handler, goroutines it started still see its span
+	if span, _ := handlerCtx.Value(spanContextKey{}).(*recordedSpan); span != handlerSpan {
+		t.Errorf("expected the handler context to carry the handler span after the handler returned, got %+v", span)
+	}
+}
+
+func TestMiddlewareSpansNest(t *testing.T) {
+	tracer := &recordingTracer{}
+	harness := slackertest.NewHarness(slacker.WithTracer(tracer))
+	defer harness.Close()
+
+	passThrough := func(next slacker.CommandHandler) slacker.CommandHandler {
+		return func(ctx *slacker.CommandContext) {
+			next(ctx)
+		}
+	}
+	harness.Bot().AddCommand(&slacker.CommandDefinition{
+		Command:     "ping",
+		Middlewares: []slacker.CommandMiddlewareHandler{passThrough, passThrough},
+		Handler:     func(*slacker.CommandContext) {},
+	})
+
+	harness.SendMessage("C123", "U123", "ping")
+
+	handlerSpan := tracer.find("slacker.handler")
+	middlewareSpans := tracer.findAll("slacker.middleware")
+	invokeSpan := tracer.find("slacker.handler.invoke")
+	// Both middlewares, then the parameter validation
+	if handlerSpan == nil || len(middlewareSpans) != 3 || invokeSpan == nil {
+		t.Fatalf("expected handler, middleware and invoke spans, got %v %v %v", handlerSpan, middlewareSpans, invokeSpan)
+	}
+
+	// handler > middleware 0 > middleware 1 > middleware 2 > invoke
+	chain := append(append([]*recordedSpan{handlerSpan}, middlewareSpans...), invokeSpan)
+	for i := 1; i < len(chain); i++ {
+		if chain[i].parent != chain[i-1] {
+			t.Errorf("expected span %d to be a child of span %d, got %+v", i, i-1, chain[i].parent)
+		}
+		if !chain[i].ended {
+			t.Errorf("expected span %d to be ended", i)
+		}
+	}
+}
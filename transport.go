@@ -0,0 +1,16 @@
+package slacker
+
+// TransportMode instructs the bot on how to receive events from Slack.
+type TransportMode int
+
+const (
+	// TransportModeSocket receives events over a Socket Mode WebSocket
+	// connection. An App-Level Token is required for this mode.
+	TransportModeSocket TransportMode = iota
+
+	// TransportModeHTTP receives events through the Events API request URLs.
+	// The bot serves `/slack/events`, `/slack/commands` and
+	// `/slack/interactions` and verifies every request using the app's
+	// Signing Secret.
+	TransportModeHTTP
+)
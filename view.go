@@ -0,0 +1,392 @@
+package slacker
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/slack-go/slack"
+)
+
+const (
+	invalidInputMessage = "Please enter a valid value"
+	missingTriggerID    = "missing trigger ID, views can only be opened from slash commands and interactions"
+)
+
+// InputType represents the kind of input of a modal
+type InputType int
+
+const (
+	// InputTypeText is a single line text input
+	InputTypeText InputType = iota
+
+	// InputTypeMultilineText is a multi-line text input
+	InputTypeMultilineText
+
+	// InputTypeNumber is a number input, decimals allowed
+	InputTypeNumber
+
+	// InputTypeSelect is a menu selecting one of the choices
+	InputTypeSelect
+
+	// InputTypeMultiSelect is a menu selecting any of the choices
+	InputTypeMultiSelect
+
+	// InputTypeCheckboxes are checkboxes selecting any of the choices
+	InputTypeCheckboxes
+
+	// InputTypeRadio are radio buttons selecting one of the choices
+	InputTypeRadio
+
+	// InputTypeDate is a date picker, bound to time.Time fields
+	InputTypeDate
+
+	// InputTypeUser is a menu selecting a user ID
+	InputTypeUser
+
+	// InputTypeChannel is a menu selecting a channel ID
+	InputTypeChannel
+)
+
+// InputOption an option for modal input values
+type InputOption func(*inputOptions)
+
+// WithOptional lets the input be left empty
+func WithOptional() InputOption {
+	return func(defaults *inputOptions) {
+		defaults.Optional = true
+	}
+}
+
+// WithPlaceholder sets the text shown while the input is empty
+func WithPlaceholder(placeholder string) InputOption {
+	return func(defaults *inputOptions) {
+		defaults.Placeholder = placeholder
+	}
+}
+
+// WithHint sets the text shown below the input
+func WithHint(hint string) InputOption {
+	return func(defaults *inputOptions) {
+		defaults.Hint = hint
+	}
+}
+
+// WithInitialValue sets the value of the input when the modal opens. For
+// choices, users, channels and dates, it is the selected value.
+func WithInitialValue(value string) InputOption {
+	return func(defaults *inputOptions) {
+		defaults.InitialValue = value
+	}
+}
+
+// WithChoices sets the values of selects, checkboxes and radio buttons, each shown as is
+func WithChoices(choices ...string) InputOption {
+	return func(defaults *inputOptions) {
+		defaults.Choices = choices
+	}
+}
+
+type inputOptions struct {
+	Optional     bool
+	Placeholder  string
+	Hint         string
+	InitialValue string
+	Choices      []string
+}
+
+// newInputOptions builds our InputOptions from zero or more InputOption.
+func newInputOptions(options ...InputOption) *inputOptions {
+	config := &inputOptions{}
+	for _, option := range options {
+		option(config)
+	}
+	return config
+}
+
+// NewModal creates a modal builder. The callback ID is matched by the
+// InteractionID of view submission and view closed interactions.
+func NewModal(callbackID string, title string) *Modal {
+	return &Modal{view: slack.ModalViewRequest{
+		Type:       slack.VTModal,
+		CallbackID: callbackID,
+		Title:      plainText(title),
+	}}
+}
+
+// Modal builds modal views out of typed inputs. Each input uses its ID as both
+// block ID and action ID, so that its value can be bound with InteractionContext.BindView.
+type Modal struct {
+	view slack.ModalViewRequest
+}
+
+// Submit sets the text of the submit button
+func (m *Modal) Submit(text string) *Modal {
+	m.view.Submit = plainText(text)
+	return m
+}
+
+// Close sets the text of the close button
+func (m *Modal) Close(text string) *Modal {
+	m.view.Close = plainText(text)
+	return m
+}
+
+// NotifyOnClose sends a view closed interaction when the user closes the modal
+func (m *Modal) NotifyOnClose() *Modal {
+	m.view.NotifyOnClose = true
+	return m
+}
+
+// PrivateMetadata sets data sent along with the view interactions, hidden from users
+func (m *Modal) PrivateMetadata(metadata string) *Modal {
+	m.view.PrivateMetadata = metadata
+	return m
+}
+
+// Text adds a section of markdown text
+func (m *Modal) Text(text string) *Modal {
+	section := slack.NewSectionBlock(slack.NewTextBlockObject(slack.MarkdownType, text, false, false), nil, nil)
+	m.view.Blocks.BlockSet = append(m.view.Blocks.BlockSet, section)
+	return m
+}
+
+// Input adds an input of the type, identified by the ID
+func (m *Modal) Input(id string, label string, inputType InputType, options ...InputOption) *Modal {
+	inputOptions := newInputOptions(options...)
+
+	input := slack.NewInputBlock(id, plainText(label), nil, newInputElement(id, inputType, inputOptions))
+	input.Optional = inputOptions.Optional
+	if len(inputOptions.Hint) > 0 {
+		input.Hint = plainText(inputOptions.Hint)
+	}
+
+	m.view.Blocks.BlockSet = append(m.view.Blocks.BlockSet, input)
+	return m
+}
+
+// Build returns the modal view, to open, push or update
+func (m *Modal) Build() slack.ModalViewRequest {
+	return m.view
+}
+
+// newInputElement creates the element of an input of the type
+func newInputElement(id string, inputType InputType, options *inputOptions) slack.BlockElement {
+	var placeholder *slack.TextBlockObject
+	if len(options.Placeholder) > 0 {
+		placeholder = plainText(options.Placeholder)
+	}
+
+	choices := make([]*slack.OptionBlockObject, 0, len(options.Choices))
+	var initialChoice *slack.OptionBlockObject
+	for _, choice := range options.Choices {
+		option := slack.NewOptionBlockObject(choice, plainText(choice), nil)
+		if choice == options.InitialValue {
+			initialChoice = option
+		}
+		choices = append(choices, option)
+	}
+
+	switch inputType {
+	case InputTypeMultilineText:
+		element := slack.NewPlainTextInputBlockElement(placeholder, id)
+		element.Multiline = true
+		element.InitialValue = options.InitialValue
+		return element
+	case InputTypeNumber:
+		element := slack.NewNumberInputBlockElement(placeholder, id, true)
+		element.InitialValue = options.InitialValue
+		return element
+	case InputTypeSelect:
+		element := slack.NewOptionsSelectBlockElement(slack.OptTypeStatic, placeholder, id, choices...)
+		element.InitialOption = initialChoice
+		return element
+	case InputTypeMultiSelect:
+		element := slack.NewOptionsMultiSelectBlockElement(slack.MultiOptTypeStatic, placeholder, id, choices...)
+		if initialChoice != nil {
+			element.InitialOptions = []*slack.OptionBlockObject{initialChoice}
+		}
+		return element
+	case InputTypeCheckboxes:
+		element := slack.NewCheckboxGroupsBlockElement(id, choices...)
+		if initialChoice != nil {
+			element.InitialOptions = []*slack.OptionBlockObject{initialChoice}
+		}
+		return element
+	case InputTypeRadio:
+		element := slack.NewRadioButtonsBlockElement(id, choices...)
+		element.InitialOption = initialChoice
+		return element
+	case InputTypeDate:
+		element := slack.NewDatePickerBlockElement(id)
+		element.Placeholder = placeholder
+		element.InitialDate = options.InitialValue
+		return element
+	case InputTypeUser:
+		element := slack.NewOptionsSelectBlockElement(slack.OptTypeUser, placeholder, id)
+		element.InitialUser = options.InitialValue
+		return element
+	case InputTypeChannel:
+		element := slack.NewOptionsSelectBlockElement(slack.OptTypeChannels, placeholder, id)
+		element.InitialChannel = options.InitialValue
+		return element
+	default:
+		element := slack.NewPlainTextInputBlockElement(placeholder, id)
+		element.InitialValue = options.InitialValue
+		return element
+	}
+}
+
+func plainText(text string) *slack.TextBlockObject {
+	return slack.NewTextBlockObject(slack.PlainTextType, text, false, false)
+}
+
+// ViewErrors contains the errors of a view submission, keyed by the block ID
+// of the inputs they are shown under
+type ViewErrors map[string]string
+
+// Error returns the errors, sorted by block ID
+func (e ViewErrors) Error() string {
+	blockIDs := make([]string, 0, len(e))
+	for blockID := range e {
+		blockIDs = append(blockIDs, blockID)
+	}
+	sort.Strings(blockIDs)
+
+	errs := make([]string, 0, len(blockIDs))
+	for _, blockID := range blockIDs {
+		errs = append(errs, blockID+": "+e[blockID])
+	}
+	return strings.Join(errs, "; ")
+}
+
+// ViewValidator is implemented by view submissions validating their values
+// once bound. Errors are keyed by the block ID of the inputs.
+type ViewValidator interface {
+	Validate() ViewErrors
+}
+
+// TypedViewSubmissionHandler adapts a handler receiving the values of a view
+// submission bound to a struct into an InteractionHandler. See
+// InteractionContext.BindView for the binding rules. Values that cannot be
+// bound, or failing validation if the struct implements ViewValidator, are
+// shown as errors under the inputs and the view stays open.
+func TypedViewSubmissionHandler[T any](handler func(*InteractionContext, *T)) InteractionHandler {
+	return func(ctx *InteractionContext) {
+		var values T
+		if err := ctx.BindView(&values); err != nil {
+			var viewErrors ViewErrors
+			if errors.As(err, &viewErrors) {
+				ctx.AckErrors(viewErrors)
+				return
+			}
+
+			ctx.err = err
+			return
+		}
+
+		if validator, ok := any(&values).(ViewValidator); ok {
+			if viewErrors := validator.Validate(); len(viewErrors) > 0 {
+				ctx.AckErrors(viewErrors)
+				return
+			}
+		}
+		handler(ctx, &values)
+	}
+}
+
+// bindViewValues sets the fields of a struct tagged with `slacker:"id"` from
+// the view state values of the inputs with that block ID
+func bindViewValues(values map[string]map[string]slack.BlockAction, out any) error {
+	target := reflect.ValueOf(out)
+	if target.Kind() != reflect.Pointer || target.IsNil() || target.Elem().Kind() != reflect.Struct {
+		return errInvalidBindTarget
+	}
+
+	viewErrors := ViewErrors{}
+
+	target = target.Elem()
+	for i := 0; i < target.NumField(); i++ {
+		field := target.Type().Field(i)
+		name := field.Tag.Get(bindTag)
+		if len(name) == 0 || name == bindSkipTag || !field.IsExported() {
+			continue
+		}
+
+		action, ok := findInputAction(values[name], name)
+		if !ok {
+			continue
+		}
+
+		inputValues := actionValues(action)
+		if len(inputValues) == 0 {
+			continue
+		}
+
+		if err := bindInputValues(target.Field(i), inputValues); err != nil {
+			viewErrors[name] = invalidInputMessage
+		}
+	}
+
+	if len(viewErrors) > 0 {
+		return viewErrors
+	}
+	return nil
+}
+
+// findInputAction returns the action of the input block, preferably the one
+// sharing the block's ID as inputs built with Modal do
+func findInputAction(actions map[string]slack.BlockAction, blockID string) (slack.BlockAction, bool) {
+	if action, ok := actions[blockID]; ok {
+		return action, true
+	}
+
+	for _, action := range actions {
+		return action, true
+	}
+	return slack.BlockAction{}, false
+}
+
+// actionValues returns the values submitted with the action, whatever its element type
+func actionValues(action slack.BlockAction) []string {
+	values := []string{}
+	for _, value := range []string{
+		action.Value,
+		action.SelectedOption.Value,
+		action.SelectedDate,
+		action.SelectedTime,
+		action.SelectedUser,
+		action.SelectedChannel,
+		action.SelectedConversation,
+	} {
+		if len(value) > 0 {
+			values = append(values, value)
+		}
+	}
+
+	for _, option := range action.SelectedOptions {
+		values = append(values, option.Value)
+	}
+
+	values = append(values, action.SelectedUsers...)
+	values = append(values, action.SelectedChannels...)
+	values = append(values, action.SelectedConversations...)
+	return values
+}
+
+// bindInputValues sets the field from the values, all of them for slices
+func bindInputValues(field reflect.Value, values []string) error {
+	if field.Kind() != reflect.Slice {
+		return bindField(field, values[0], nil)
+	}
+
+	slice := reflect.MakeSlice(field.Type(), len(values), len(values))
+	for i, value := range values {
+		if err := bindField(slice.Index(i), value, nil); err != nil {
+			return err
+		}
+	}
+	field.Set(slice)
+	return nil
+}
@@ -0,0 +1,167 @@
+package slacker_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/shomali11/slacker/v2"
+	"github.com/shomali11/slacker/v2/slackertest"
+	"github.com/slack-go/slack"
+)
+
+// incident contains the values of the incident modal
+type incident struct {
+	Title    string    `slacker:"title"`
+	Severity int       `slacker:"severity"`
+	Budget   float64   `slacker:"budget"`
+	Teams    []string  `slacker:"teams"`
+	Due      time.Time `slacker:"due"`
+	Owner    string    `slacker:"owner"`
+	Notes    string    `slacker:"notes"`
+}
+
+// Validate rejects incidents with a severity out of range
+func (i *incident) Validate() slacker.ViewErrors {
+	if i.Severity < 1 || i.Severity > 5 {
+		return slacker.ViewErrors{"severity": "Between 1 and 5"}
+	}
+	return nil
+}
+
+// newIncidentSubmission creates the submission of the incident modal with the values
+func newIncidentSubmission(values map[string]slack.BlockAction) *slack.InteractionCallback {
+	callback := &slack.InteractionCallback{Type: slack.InteractionTypeViewSubmission}
+	callback.User.ID = "U123"
+	callback.View.CallbackID = "incident"
+	callback.View.State = &slack.ViewState{Values: map[string]map[string]slack.BlockAction{}}
+	for id, action := range values {
+		callback.View.State.Values[id] = map[string]slack.BlockAction{id: action}
+	}
+	return callback
+}
+
+// addIncidentHandler handles the incident modal submissions, returning the bound values
+func addIncidentHandler(harness *slackertest.Harness) **incident {
+	var submitted *incident
+	harness.Bot().AddInteraction(&slacker.InteractionDefinition{
+		Type:          slack.InteractionTypeViewSubmission,
+		InteractionID: "incident",
+		Handler: slacker.TypedViewSubmissionHandler(func(ctx *slacker.InteractionContext, values *incident) {
+			submitted = values
+		}),
+	})
+	return &submitted
+}
+
+func TestModal(t *testing.T) {
+	view := slacker.NewModal("incident", "Incident").
+		Submit("Open").
+		Input("title", "Title", slacker.InputTypeText, slacker.WithPlaceholder("What happened?")).
+		Input("severity", "Severity", slacker.InputTypeSelect, slacker.WithChoices("1", "2", "3"), slacker.WithInitialValue("2")).
+		Input("due", "Due", slacker.InputTypeDate, slacker.WithOptional()).
+		Build()
+
+	if view.Type != slack.VTModal || view.CallbackID != "incident" || view.Submit.Text != "Open" {
+		t.Errorf("expected a modal matched by its callback ID, got %+v", view)
+	}
+
+	blocks := view.Blocks.BlockSet
+	if len(blocks) != 3 {
+		t.Fatalf("expected 3 inputs, got %d", len(blocks))
+	}
+
+	title := blocks[0].(*slack.InputBlock)
+	titleElement := title.Element.(*slack.PlainTextInputBlockElement)
+	if title.BlockID != "title" || titleElement.ActionID != "title" || titleElement.Placeholder.Text != "What happened?" {
+		t.Errorf("expected the input ID as block and action IDs, got %+v %+v", title, titleElement)
+	}
+
+	severity := blocks[1].(*slack.InputBlock).Element.(*slack.SelectBlockElement)
+	if len(severity.Options) != 3 || severity.InitialOption == nil || severity.InitialOption.Value != "2" {
+		t.Errorf("expected the choices with the initial one selected, got %+v", severity)
+	}
+
+	due := blocks[2].(*slack.InputBlock)
+	if _, ok := due.Element.(*slack.DatePickerBlockElement); !ok || !due.Optional {
+		t.Errorf("expected an optional date picker, got %+v", due)
+	}
+}
+
+func TestTypedViewSubmissionHandler(t *testing.T) {
+	harness := slackertest.NewHarness()
+	defer harness.Close()
+
+	submitted := addIncidentHandler(harness)
+
+	payload := harness.SubmitInteraction(newIncidentSubmission(map[string]slack.BlockAction{
+		"title":    {Value: "Database down"},
+		"severity": {SelectedOption: slack.OptionBlockObject{Value: "2"}},
+		"budget":   {Value: "12.5"},
+		"teams":    {SelectedOptions: []slack.OptionBlockObject{{Value: "db"}, {Value: "ops"}}},
+		"due":      {SelectedDate: "2023-03-01"},
+		"owner":    {SelectedUser: "U456"},
+		"notes":    {},
+	}))
+
+	if payload != nil {
+		t.Errorf("expected the submission to be acknowledged without payload, got %+v", payload)
+	}
+
+	expected := &incident{
+		Title:    "Database down",
+		Severity: 2,
+		Budget:   12.5,
+		Teams:    []string{"db", "ops"},
+		Due:      time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC),
+		Owner:    "U456",
+	}
+	if !reflect.DeepEqual(*submitted, expected) {
+		t.Errorf("expected the values to be bound, got %+v", *submitted)
+	}
+}
+
+func TestTypedViewSubmissionHandlerInvalidValues(t *testing.T) {
+	harness := slackertest.NewHarness()
+	defer harness.Close()
+
+	submitted := addIncidentHandler(harness)
+
+	payload := harness.SubmitInteraction(newIncidentSubmission(map[string]slack.BlockAction{
+		"severity": {Value: "high"},
+		"due":      {SelectedDate: "tomorrow"},
+	}))
+
+	response, ok := payload.(*slack.ViewSubmissionResponse)
+	if !ok || response.ResponseAction != slack.RAErrors {
+		t.Fatalf("expected the submission to be acknowledged with errors, got %+v", payload)
+	}
+
+	expected := map[string]string{"severity": "Please enter a valid value", "due": "Please enter a valid value"}
+	if !reflect.DeepEqual(response.Errors, expected) {
+		t.Errorf("expected errors under the invalid inputs, got %+v", response.Errors)
+	}
+	if *submitted != nil {
+		t.Error("expected the handler not to run")
+	}
+}
+
+func TestTypedViewSubmissionHandlerValidation(t *testing.T) {
+	harness := slackertest.NewHarness()
+	defer harness.Close()
+
+	submitted := addIncidentHandler(harness)
+
+	payload := harness.SubmitInteraction(newIncidentSubmission(map[string]slack.BlockAction{
+		"title":    {Value: "Database down"},
+		"severity": {Value: "9"},
+	}))
+
+	response, ok := payload.(*slack.ViewSubmissionResponse)
+	if !ok || response.ResponseAction != slack.RAErrors || response.Errors["severity"] != "Between 1 and 5" {
+		t.Fatalf("expected the validation errors to be acknowledged, got %+v", payload)
+	}
+	if *submitted != nil {
+		t.Error("expected the handler not to run")
+	}
+}
@@ -0,0 +1,187 @@
+package slacker
+
+import (
+	"hash/fnv"
+	"sync"
+)
+
+const (
+	defaultBusyMessage = "I am a little busy right now, please try again in a moment."
+)
+
+// OverflowPolicy instructs the worker pool on how to handle events once its queue is full.
+type OverflowPolicy int
+
+const (
+	// OverflowPolicyBlock waits for room in the queue, slowing down the
+	// processing of incoming events.
+	OverflowPolicyBlock OverflowPolicy = iota
+
+	// OverflowPolicyDropOldest discards the oldest queued event to make room
+	// for the incoming one. Without a queue, events wait for a worker.
+	OverflowPolicyDropOldest
+
+	// OverflowPolicyReplyBusy discards the incoming event and lets the user
+	// know the bot is busy with an ephemeral message, when possible.
+	OverflowPolicyReplyBusy
+)
+
+// work contains a queued handler along with how to discard it
+type work struct {
+	key  string
+	run  func()
+	drop func(busy bool)
+}
+
+// newClientWorkerPool creates the worker pool configured by the client options, if any
+func newClientWorkerPool(options *clientOptions) *workerPool {
+	if options.Workers <= 0 {
+		if options.ChannelSerialization {
+			options.StructuredLogger.Warn("channel serialization requires a worker pool, ignored")
+		}
+		return nil
+	}
+
+	queueSize := options.QueueSize
+	if queueSize < 0 {
+		queueSize = 0
+	}
+
+	if queueSize == 0 && options.OverflowPolicy == OverflowPolicyDropOldest {
+		options.StructuredLogger.Warn("dropping the oldest event requires a queue, events wait for a worker instead")
+	}
+	return newWorkerPool(options.StructuredLogger, options.Workers, queueSize, options.OverflowPolicy, options.ChannelSerialization)
+}
+
+// newWorkerPool creates a new worker pool structure. When serializing, every
+// worker has its own queue and work with the same key always lands on the
+// same worker, which preserves its order.
+func newWorkerPool(logger StructuredLogger, workers int, queueSize int, policy OverflowPolicy, serialize bool) *workerPool {
+	return &workerPool{
+		logger:    logger,
+		workers:   workers,
+		queueSize: queueSize,
+		policy:    policy,
+		serialize: serialize,
+	}
+}
+
+// workerPool runs work on a bounded number of goroutines
+type workerPool struct {
+	logger    StructuredLogger
+	workers   int
+	queueSize int
+	policy    OverflowPolicy
+	serialize bool
+	queues    []chan *work
+	done      chan struct{}
+	mutex     sync.Mutex
+	next      uint32
+}
+
+// start creates the queues and spawns the workers
+func (p *workerPool) start() {
+	queueCount := 1
+	if p.serialize {
+		queueCount = p.workers
+	}
+
+	p.queues = make([]chan *work, queueCount)
+	for i := range p.queues {
+		p.queues[i] = make(chan *work, p.queueSize)
+	}
+	p.done = make(chan struct{})
+
+	for i := 0; i < p.workers; i++ {
+		go p.work(p.queues[i%queueCount])
+	}
+}
+
+// stop terminates the workers once done with their current work and discards
+// any work left in the queues
+func (p *workerPool) stop() {
+	close(p.done)
+
+	for _, queue := range p.queues {
+		for {
+			select {
+			case w := <-queue:
+				w.drop(false)
+				continue
+			default:
+			}
+			break
+		}
+	}
+}
+
+// submit queues the work, applying the overflow policy if the queue is full
+func (p *workerPool) submit(w *work) {
+	queue := p.queue(w.key)
+
+	switch p.policy {
+	case OverflowPolicyDropOldest:
+		select {
+		case queue <- w:
+			return
+		default:
+		}
+
+		select {
+		case oldest := <-queue:
+			p.logger.Debug("worker pool queue is full, dropped oldest event")
+			oldest.drop(false)
+		default:
+		}
+
+		// Wait for room when there was nothing to evict, or another event
+		// took the evicted one's place
+		select {
+		case queue <- w:
+		case <-p.done:
+			w.drop(false)
+		}
+	case OverflowPolicyReplyBusy:
+		select {
+		case queue <- w:
+		default:
+			p.logger.Debug("worker pool queue is full, dropped incoming event")
+			w.drop(true)
+		}
+	default:
+		select {
+		case queue <- w:
+		case <-p.done:
+			w.drop(false)
+		}
+	}
+}
+
+func (p *workerPool) queue(key string) chan *work {
+	if len(p.queues) == 1 {
+		return p.queues[0]
+	}
+
+	if len(key) == 0 {
+		p.mutex.Lock()
+		p.next++
+		index := p.next % uint32(len(p.queues))
+		p.mutex.Unlock()
+		return p.queues[index]
+	}
+
+	hash := fnv.New32a()
+	hash.Write([]byte(key))
+	return p.queues[hash.Sum32()%uint32(len(p.queues))]
+}
+
+func (p *workerPool) work(queue chan *work) {
+	for {
+		select {
+		case <-p.done:
+			return
+		case w := <-queue:
+			w.run()
+		}
+	}
+}
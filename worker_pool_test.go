@@ -0,0 +1,243 @@
+package slacker
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newTestWorkerPool(t *testing.T, workers int, queueSize int, policy OverflowPolicy, serialize bool) *workerPool {
+	pool := newWorkerPool(NewPrintfLogger(newBuiltinLogger(false)), workers, queueSize, policy, serialize)
+	pool.start()
+	t.Cleanup(pool.stop)
+	return pool
+}
+
+// occupy keeps the single worker of the pool busy until release is closed
+func occupy(pool *workerPool, release chan struct{}) {
+	started := make(chan struct{})
+	pool.submit(&work{
+		run: func() {
+			close(started)
+			<-release
+		},
+		drop: func(bool) {},
+	})
+	<-started
+}
+
+// poolRecorder records what happens to the work it creates
+type poolRecorder struct {
+	mutex   sync.Mutex
+	dropped []string
+	busy    []string
+	ran     chan string
+}
+
+func newPoolRecorder() *poolRecorder {
+	return &poolRecorder{ran: make(chan string, 100)}
+}
+
+func (r *poolRecorder) work(name string) *work {
+	return &work{
+		run: func() {
+			r.ran <- name
+		},
+		drop: func(busy bool) {
+			r.mutex.Lock()
+			defer r.mutex.Unlock()
+
+			if busy {
+				r.busy = append(r.busy, name)
+			} else {
+				r.dropped = append(r.dropped, name)
+			}
+		},
+	}
+}
+
+func (r *poolRecorder) discarded() ([]string, []string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.dropped, r.busy
+}
+
+func (r *poolRecorder) expectRan(t *testing.T, names ...string) {
+	t.Helper()
+
+	for _, name := range names {
+		select {
+		case ran := <-r.ran:
+			if ran != name {
+				t.Fatalf("expected %s to run, got %s", name, ran)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("expected %s to run", name)
+		}
+	}
+}
+
+func TestWorkerPoolBlock(t *testing.T) {
+	pool := newTestWorkerPool(t, 1, 1, OverflowPolicyBlock, false)
+	recorder := newPoolRecorder()
+
+	release := make(chan struct{})
+	occupy(pool, release)
+	pool.submit(recorder.work("queued"))
+
+	// The queue is full, submitting waits for the worker to make room
+	submitted := make(chan struct{})
+	go func() {
+		pool.submit(recorder.work("waiting"))
+		close(submitted)
+	}()
+
+	close(release)
+	<-submitted
+	recorder.expectRan(t, "queued", "waiting")
+
+	if dropped, busy := recorder.discarded(); len(dropped) != 0 || len(busy) != 0 {
+		t.Errorf("expected nothing discarded, got %q %q", dropped, busy)
+	}
+}
+
+func TestWorkerPoolDropOldest(t *testing.T) {
+	pool := newTestWorkerPool(t, 1, 1, OverflowPolicyDropOldest, false)
+	recorder := newPoolRecorder()
+
+	release := make(chan struct{})
+	occupy(pool, release)
+	pool.submit(recorder.work("oldest"))
+	pool.submit(recorder.work("newest"))
+
+	if dropped, busy := recorder.discarded(); !reflect.DeepEqual(dropped, []string{"oldest"}) || len(busy) != 0 {
+		t.Errorf("expected the oldest work to be dropped, got %q %q", dropped, busy)
+	}
+
+	close(release)
+	recorder.expectRan(t, "newest")
+}
+
+func TestWorkerPoolReplyBusy(t *testing.T) {
+	pool := newTestWorkerPool(t, 1, 1, OverflowPolicyReplyBusy, false)
+	recorder := newPoolRecorder()
+
+	release := make(chan struct{})
+	occupy(pool, release)
+	pool.submit(recorder.work("queued"))
+	pool.submit(recorder.work("incoming"))
+
+	if dropped, busy := recorder.discarded(); len(dropped) != 0 || !reflect.DeepEqual(busy, []string{"incoming"}) {
+		t.Errorf("expected the incoming work to be discarded as busy, got %q %q", dropped, busy)
+	}
+
+	close(release)
+	recorder.expectRan(t, "queued")
+}
+
+func TestWorkerPoolSerializesKeys(t *testing.T) {
+	pool := newTestWorkerPool(t, 4, 10, OverflowPolicyBlock, true)
+
+	var mutex sync.Mutex
+	var waiter sync.WaitGroup
+	handled := make(map[string][]int)
+
+	keys := []string{"C1", "C2", "C3", "C4", "C5"}
+	for i := 0; i < 50; i++ {
+		for _, key := range keys {
+			key, i := key, i
+			waiter.Add(1)
+			pool.submit(&work{
+				key: key,
+				run: func() {
+					defer waiter.Done()
+
+					mutex.Lock()
+					handled[key] = append(handled[key], i)
+					mutex.Unlock()
+				},
+				drop: func(bool) {
+					waiter.Done()
+					t.Errorf("unexpected drop of %s %d", key, i)
+				},
+			})
+		}
+	}
+	waiter.Wait()
+
+	for _, key := range keys {
+		for i, value := range handled[key] {
+			if value != i {
+				t.Fatalf("expected the work of %s to be handled in order, got %v", key, handled[key])
+			}
+		}
+		if len(handled[key]) != 50 {
+			t.Errorf("expected all the work of %s to be handled, got %d", key, len(handled[key]))
+		}
+	}
+}
+
+func TestDispatcherRepliesBusy(t *testing.T) {
+	ephemerals := make(chan url.Values, 1)
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/chat.postEphemeral" {
+			r.ParseForm()
+			ephemerals <- r.PostForm
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer api.Close()
+
+	bot := NewClient("xoxb-test", "xapp-test",
+		WithTransportMode(TransportModeHTTP),
+		WithSigningSecret(testSigningSecret),
+		WithAPIURL(api.URL+"/"),
+		WithWorkerPool(1, 1),
+		WithOverflowPolicy(OverflowPolicyReplyBusy),
+		WithBusyMessage("Busy, try again"),
+	)
+
+	jobsDone := make(chan struct{})
+	close(jobsDone)
+	bot.dispatcher.start(context.Background())
+	defer bot.dispatcher.shutdown(time.Second, jobsDone)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+
+	bot.dispatcher.dispatch(&task{
+		name: "occupying",
+		handler: func(context.Context) {
+			close(started)
+			<-release
+		},
+	})
+	<-started
+
+	for i := 0; i < 2; i++ {
+		bot.dispatcher.dispatch(&task{
+			name:    fmt.Sprintf("task %d", i),
+			key:     "C123",
+			handler: func(context.Context) {},
+			busy:    bot.replyBusy("C123", "U123"),
+		})
+	}
+
+	select {
+	case form := <-ephemerals:
+		if form.Get("channel") != "C123" || form.Get("user") != "U123" || form.Get("text") != "Busy, try again" {
+			t.Errorf("expected the busy message to be sent to the user, got %v", form)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the busy message to be sent")
+	}
+}